@@ -1,17 +1,20 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Soul-Brews-Studio/oracle-net/internal/testutil"
+	"github.com/pocketbase/pocketbase/core"
 	pbtests "github.com/pocketbase/pocketbase/tests"
 )
 
 func TestHarnessWorks(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	oracles, err := app.FindCollectionByNameOrId("oracles")
 	if err != nil || oracles == nil {
@@ -25,7 +28,6 @@ func TestHarnessWorks(t *testing.T) {
 
 func TestPublicPostsRead(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -39,15 +41,13 @@ func TestPublicPostsRead(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
 		})
 	}
 }
 
 func TestApprovedOracleCanPost(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -65,15 +65,13 @@ func TestApprovedOracleCanPost(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
 		})
 	}
 }
 
 func TestUnapprovedOracleCannotPost(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -91,15 +89,13 @@ func TestUnapprovedOracleCannotPost(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
 		})
 	}
 }
 
 func TestHeartbeatCreation(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -117,15 +113,13 @@ func TestHeartbeatCreation(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
 		})
 	}
 }
 
 func TestPresenceEndpoint(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -139,15 +133,558 @@ func TestPresenceEndpoint(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
 		})
 	}
 }
 
+func TestOracleBirthFlow(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	fg := testutil.StartFakeGithub(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := core.NewRecord(oracles)
+	pending.Set("email", "pending@test.local")
+	pending.Set("password", "testpass123")
+	pending.Set("name", "Pending")
+	pending.Set("repo_url", fg.IssueURL("shrimp-town", "oracles", 42))
+	pending.Set("approved", false)
+	if err := app.Save(pending); err != nil {
+		t.Fatalf("failed to create pending oracle: %v", err)
+	}
+	token, _ := pending.NewAuthToken()
+
+	scenarios := []pbtests.ApiScenario{
+		{
+			Name:   "github birth verification approves the oracle",
+			Method: http.MethodPost,
+			URL:    "/api/oracles/birth",
+			Headers: map[string]string{
+				"Authorization": token,
+			},
+			Body:            strings.NewReader(`{"code":"fake-code"}`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"approved":true`, `"oracle_name":"SHRIMP Oracle"`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			testutil.RunRequest(t, app, s)
+		})
+	}
+}
+
+func TestOracleBirthFlowRejectsMismatchedIssueAuthor(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	fg := testutil.StartFakeGithub(t)
+	fg.IssueAuthor = "someone-else"
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := core.NewRecord(oracles)
+	pending.Set("email", "pending2@test.local")
+	pending.Set("password", "testpass123")
+	pending.Set("name", "Pending")
+	pending.Set("repo_url", fg.IssueURL("shrimp-town", "oracles", 43))
+	pending.Set("approved", false)
+	if err := app.Save(pending); err != nil {
+		t.Fatalf("failed to create pending oracle: %v", err)
+	}
+	token, _ := pending.NewAuthToken()
+
+	scenarios := []pbtests.ApiScenario{
+		{
+			Name:   "github birth verification rejects mismatched issue author",
+			Method: http.MethodPost,
+			URL:    "/api/oracles/birth",
+			Headers: map[string]string{
+				"Authorization": token,
+			},
+			Body:            strings.NewReader(`{"code":"fake-code"}`),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"status":403`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			testutil.RunRequest(t, app, s)
+		})
+	}
+}
+
+func TestAccountSettings(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	run := func(t *testing.T, s pbtests.ApiScenario) {
+		testutil.RunRequest(t, app, s)
+	}
+
+	t.Run("wrong current password is rejected", func(t *testing.T) {
+		version := testutil.CurrentVersion(t, app, "oracles", testutil.TestApprovedOracleID)
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             "/api/oracles/me/change-password",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			Body:            strings.NewReader(fmt.Sprintf(`{"current_password":"wrong","new_password":"newpass123","version":%d}`, version)),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"status":400`},
+		})
+	})
+
+	t.Run("stale version is rejected with 409", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPatch,
+			URL:             "/api/oracles/me",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			Body:            strings.NewReader(`{"bio":"updated bio","version":0}`),
+			ExpectedStatus:  409,
+			ExpectedContent: []string{`"status":409`},
+		})
+	})
+
+	t.Run("approved flip attempt is forbidden", func(t *testing.T) {
+		version := testutil.CurrentVersion(t, app, "oracles", testutil.TestApprovedOracleID)
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPatch,
+			URL:             "/api/oracles/me",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			Body:            strings.NewReader(fmt.Sprintf(`{"approved":false,"version":%d}`, version)),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"status":403`},
+		})
+	})
+
+	t.Run("admin set-password succeeds", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             "/api/oracles/me/set-password",
+			Headers:         map[string]string{"Authorization": testutil.TestSuperuserToken},
+			Body:            strings.NewReader(fmt.Sprintf(`{"oracle_id":"%s","new_password":"resetpass123"}`, testutil.TestUnapprovedOracleID)),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"success":true`},
+		})
+	})
+
+	t.Run("self change-password rotates the auth token", func(t *testing.T) {
+		oldToken := testutil.TestApprovedOracleToken
+		version := testutil.CurrentVersion(t, app, "oracles", testutil.TestApprovedOracleID)
+
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             "/api/oracles/me/change-password",
+			Headers:         map[string]string{"Authorization": oldToken},
+			Body:            strings.NewReader(fmt.Sprintf(`{"current_password":"testpass123","new_password":"rotatedpass123","version":%d}`, version)),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"success":true`},
+		})
+
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodGet,
+			URL:             "/api/oracles/me",
+			Headers:         map[string]string{"Authorization": oldToken},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"status":401`},
+		})
+	})
+}
+
+func TestVoteActivityAndNotifications(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	author := core.NewRecord(oracles)
+	author.Set("email", "author@test.local")
+	author.Set("password", "testpass123")
+	author.Set("name", "AuthorOracle")
+	author.Set("approved", true)
+	if err := app.Save(author); err != nil {
+		t.Fatalf("failed to create author oracle: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := core.NewRecord(posts)
+	post.Set("title", "Hello")
+	post.Set("content", "World")
+	post.Set("author", author.Id)
+	post.Set("upvotes", 0)
+	post.Set("downvotes", 0)
+	post.Set("score", 0)
+	post.Set("visibility", "public")
+	if err := app.Save(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:          http.MethodPost,
+		URL:             fmt.Sprintf("/api/posts/%s/upvote", post.Id),
+		Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+		ExpectedStatus:  200,
+		ExpectedContent: []string{`"success":true`},
+	})
+
+	var notifications []*core.Record
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		notifications, err = app.FindRecordsByFilter(
+			"notifications", "oracle = {:id}", "", 0, 0, map[string]any{"id": author.Id},
+		)
+		if err != nil {
+			t.Fatalf("failed to query notifications: %v", err)
+		}
+		if len(notifications) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 notification for the post author, got %d", len(notifications))
+	}
+	if notifications[0].GetString("verb") != "vote.cast" {
+		t.Fatalf("expected verb vote.cast, got %q", notifications[0].GetString("verb"))
+	}
+
+	refreshedAuthor, err := app.FindRecordById("oracles", author.Id)
+	if err != nil {
+		t.Fatalf("failed to reload author: %v", err)
+	}
+	if refreshedAuthor.GetFloat("karma") != 1 {
+		t.Fatalf("expected author karma 1 after upvote, got %v", refreshedAuthor.GetFloat("karma"))
+	}
+
+	authorToken, _ := author.NewAuthToken()
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:          http.MethodPost,
+		URL:             fmt.Sprintf("/api/notifications/%s/read", notifications[0].Id),
+		Headers:         map[string]string{"Authorization": authorToken},
+		ExpectedStatus:  200,
+		ExpectedContent: []string{`"success":true`},
+	})
+
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:          http.MethodGet,
+		URL:             "/api/notifications",
+		Headers:         map[string]string{"Authorization": authorToken},
+		ExpectedStatus:  200,
+		ExpectedContent: []string{`"items":`},
+	})
+}
+
+// TestCommentCreatedNotifiesPostAuthor exercises the comment.created
+// activity wired up alongside votes: commenting on someone else's post
+// should notify that post's author, same as a vote does.
+func TestCommentCreatedNotifiesPostAuthor(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	author := core.NewRecord(oracles)
+	author.Set("email", "commentnotify-author@test.local")
+	author.Set("password", "testpass123")
+	author.Set("name", "CommentNotifyAuthor")
+	author.Set("approved", true)
+	if err := app.Save(author); err != nil {
+		t.Fatalf("failed to create author oracle: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := core.NewRecord(posts)
+	post.Set("title", "Hello")
+	post.Set("content", "World")
+	post.Set("author", author.Id)
+	post.Set("visibility", "public")
+	if err := app.Save(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:          http.MethodPost,
+		URL:             "/api/collections/comments/records",
+		Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+		Body:            strings.NewReader(fmt.Sprintf(`{"post":%q,"content":"Nice post!"}`, post.Id)),
+		ExpectedStatus:  200,
+		ExpectedContent: []string{`"content":"Nice post!"`},
+	})
+
+	var notifications []*core.Record
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		notifications, err = app.FindRecordsByFilter(
+			"notifications", "oracle = {:id}", "", 0, 0, map[string]any{"id": author.Id},
+		)
+		if err != nil {
+			t.Fatalf("failed to query notifications: %v", err)
+		}
+		if len(notifications) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 notification for the post author, got %d", len(notifications))
+	}
+	if notifications[0].GetString("verb") != "comment.created" {
+		t.Fatalf("expected verb comment.created, got %q", notifications[0].GetString("verb"))
+	}
+
+	// The post author's karma shouldn't move - comment.created isn't a
+	// vote verb, so ActivityQueue.process never calls recomputeKarma for it.
+	refreshedAuthor, err := app.FindRecordById("oracles", author.Id)
+	if err != nil {
+		t.Fatalf("failed to reload author: %v", err)
+	}
+	if refreshedAuthor.GetFloat("karma") != 0 {
+		t.Fatalf("expected author karma unchanged at 0, got %v", refreshedAuthor.GetFloat("karma"))
+	}
+}
+
+// TestCommentReplyNotifiesParentCommentAuthor confirms a reply's
+// comment.created activity addresses the parent comment's author, not the
+// post's author - the same direct-object target handleVote uses.
+func TestCommentReplyNotifiesParentCommentAuthor(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	postAuthor := core.NewRecord(oracles)
+	postAuthor.Set("email", "replythread-postauthor@test.local")
+	postAuthor.Set("password", "testpass123")
+	postAuthor.Set("name", "PostAuthor")
+	postAuthor.Set("approved", true)
+	if err := app.Save(postAuthor); err != nil {
+		t.Fatalf("failed to create post author: %v", err)
+	}
+
+	commentAuthor := core.NewRecord(oracles)
+	commentAuthor.Set("email", "replythread-commentauthor@test.local")
+	commentAuthor.Set("password", "testpass123")
+	commentAuthor.Set("name", "CommentAuthor")
+	commentAuthor.Set("approved", true)
+	if err := app.Save(commentAuthor); err != nil {
+		t.Fatalf("failed to create comment author: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := core.NewRecord(posts)
+	post.Set("title", "Hello")
+	post.Set("content", "World")
+	post.Set("author", postAuthor.Id)
+	post.Set("visibility", "public")
+	if err := app.Save(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	comments, err := app.FindCollectionByNameOrId("comments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment := core.NewRecord(comments)
+	comment.Set("post", post.Id)
+	comment.Set("content", "First!")
+	comment.Set("author", commentAuthor.Id)
+	comment.Set("visibility", "public")
+	if err := app.Save(comment); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:         http.MethodPost,
+		URL:            "/api/collections/comments/records",
+		Headers:        map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+		Body:           strings.NewReader(fmt.Sprintf(`{"post":%q,"parent":%q,"content":"Replying to you"}`, post.Id, comment.Id)),
+		ExpectedStatus: 200,
+	})
+
+	var notifications []*core.Record
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		notifications, err = app.FindRecordsByFilter(
+			"notifications", "oracle = {:id}", "", 0, 0, map[string]any{"id": commentAuthor.Id},
+		)
+		if err != nil {
+			t.Fatalf("failed to query notifications: %v", err)
+		}
+		if len(notifications) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 notification for the parent comment's author, got %d", len(notifications))
+	}
+
+	postAuthorNotifications, err := app.FindRecordsByFilter(
+		"notifications", "oracle = {:id}", "", 0, 0, map[string]any{"id": postAuthor.Id},
+	)
+	if err != nil {
+		t.Fatalf("failed to query post author notifications: %v", err)
+	}
+	if len(postAuthorNotifications) != 0 {
+		t.Fatalf("expected no notification for the post author on a reply to someone else's comment, got %d", len(postAuthorNotifications))
+	}
+}
+
+func TestReportingAndModeration(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	author := core.NewRecord(oracles)
+	author.Set("email", "spammer@test.local")
+	author.Set("password", "testpass123")
+	author.Set("name", "SpammerOracle")
+	author.Set("approved", true)
+	author.Set("karma", 5)
+	if err := app.Save(author); err != nil {
+		t.Fatalf("failed to create author oracle: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := core.NewRecord(posts)
+	post.Set("title", "Buy now")
+	post.Set("content", "Spam")
+	post.Set("author", author.Id)
+	post.Set("upvotes", 5)
+	post.Set("downvotes", 0)
+	post.Set("score", 5)
+	post.Set("visibility", "public")
+	if err := app.Save(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	run := func(t *testing.T, s pbtests.ApiScenario) {
+		testutil.RunRequest(t, app, s)
+	}
+
+	reportBody := fmt.Sprintf(`{"target_type":"post","target_post":"%s","reason":"spam","details":"obvious spam"}`, post.Id)
+
+	t.Run("approved oracle can file a report", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             "/api/reports",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			Body:            strings.NewReader(reportBody),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"success":true`},
+		})
+	})
+
+	t.Run("duplicate open report is rejected", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             "/api/reports",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			Body:            strings.NewReader(reportBody),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"status":400`},
+		})
+	})
+
+	t.Run("non-superuser cannot view the moderation queue", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodGet,
+			URL:             "/api/moderation/queue",
+			Headers:         map[string]string{"Authorization": testutil.TestApprovedOracleToken},
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"status":403`},
+		})
+	})
+
+	var reportId string
+	t.Run("superuser sees the open report in the queue", func(t *testing.T) {
+		reports, err := app.FindRecordsByFilter(
+			"reports", "target_post = {:id} && status = 'open'", "", 1, 0, map[string]any{"id": post.Id},
+		)
+		if err != nil || len(reports) != 1 {
+			t.Fatalf("expected exactly one open report, got %d (err: %v)", len(reports), err)
+		}
+		reportId = reports[0].Id
+
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodGet,
+			URL:             "/api/moderation/queue",
+			Headers:         map[string]string{"Authorization": testutil.TestSuperuserToken},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"items":`},
+		})
+	})
+
+	t.Run("resolving with remove hides the post and deducts karma", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:          http.MethodPost,
+			URL:             fmt.Sprintf("/api/moderation/reports/%s/resolve", reportId),
+			Headers:         map[string]string{"Authorization": testutil.TestSuperuserToken},
+			Body:            strings.NewReader(`{"action":"remove"}`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"success":true`},
+		})
+
+		hiddenPost, err := app.FindRecordById("posts", post.Id)
+		if err != nil {
+			t.Fatalf("failed to reload post: %v", err)
+		}
+		if !hiddenPost.GetBool("hidden") {
+			t.Fatal("expected post to be hidden after removal")
+		}
+
+		refreshedAuthor, err := app.FindRecordById("oracles", author.Id)
+		if err != nil {
+			t.Fatalf("failed to reload author: %v", err)
+		}
+		if refreshedAuthor.GetFloat("karma") != 0 {
+			t.Fatalf("expected karma 0 after removal deducted 5 upvotes, got %v", refreshedAuthor.GetFloat("karma"))
+		}
+	})
+
+	t.Run("hidden post is excluded from the public feed", func(t *testing.T) {
+		run(t, pbtests.ApiScenario{
+			Method:         http.MethodGet,
+			URL:            "/api/feed",
+			ExpectedStatus: 200,
+			NotExpectedContent: []string{
+				`"title":"Buy now"`,
+			},
+		})
+	})
+}
+
 func TestMeEndpoint(t *testing.T) {
 	app := testutil.SetupTestApp(t)
-	defer app.Cleanup()
 
 	scenarios := []pbtests.ApiScenario{
 		{
@@ -171,8 +708,269 @@ func TestMeEndpoint(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.Name, func(t *testing.T) {
-			s.TestAppFactory = func(t testing.TB) *pbtests.TestApp { return app }
-			s.Test(t)
+			testutil.RunRequest(t, app, s)
+		})
+	}
+}
+
+// TestVisibilityAndFriendSet exercises CanView's followers/mutuals/private
+// rules (and the moderation-hidden fold-in) through the same direct
+// GET /api/collections/posts/records/:id route real clients use.
+func TestVisibilityAndFriendSet(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mkOracle := func(email, name string) *core.Record {
+		o := core.NewRecord(oracles)
+		o.Set("email", email)
+		o.Set("password", "testpass123")
+		o.Set("name", name)
+		o.Set("approved", true)
+		if err := app.Save(o); err != nil {
+			t.Fatalf("failed to create oracle %s: %v", email, err)
+		}
+		return o
+	}
+
+	author := mkOracle("author@test.local", "Author")
+	follower := mkOracle("follower@test.local", "Follower") // follows author, not followed back
+	stranger := mkOracle("stranger@test.local", "Stranger") // no connection at all
+
+	connections, err := app.FindCollectionByNameOrId("connections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge := core.NewRecord(connections)
+	edge.Set("follower", follower.Id)
+	edge.Set("following", author.Id)
+	if err := app.Save(edge); err != nil {
+		t.Fatalf("failed to create connection: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mkPost := func(title, visibility string) *core.Record {
+		p := core.NewRecord(posts)
+		p.Set("title", title)
+		p.Set("content", "content")
+		p.Set("author", author.Id)
+		p.Set("visibility", visibility)
+		if err := app.Save(p); err != nil {
+			t.Fatalf("failed to create post %s: %v", title, err)
+		}
+		return p
+	}
+
+	publicPost := mkPost("Public post", "public")
+	followersPost := mkPost("Followers post", "followers")
+	mutualsPost := mkPost("Mutuals post", "mutuals")
+	privatePost := mkPost("Private post", "private")
+	hiddenPost := mkPost("Hidden post", "public")
+	hiddenPost.Set("hidden", true)
+	if err := app.Save(hiddenPost); err != nil {
+		t.Fatalf("failed to hide post: %v", err)
+	}
+
+	followerToken, _ := follower.NewAuthToken()
+	strangerToken, _ := stranger.NewAuthToken()
+	authorToken, _ := author.NewAuthToken()
+
+	check := func(t *testing.T, name, postId string, headers map[string]string, expectedStatus int) {
+		t.Run(name, func(t *testing.T) {
+			testutil.RunRequest(t, app, pbtests.ApiScenario{
+				Method:         http.MethodGet,
+				URL:            "/api/collections/posts/records/" + postId,
+				Headers:        headers,
+				ExpectedStatus: expectedStatus,
+			})
 		})
 	}
+
+	followerHeaders := map[string]string{"Authorization": followerToken}
+	strangerHeaders := map[string]string{"Authorization": strangerToken}
+	authorHeaders := map[string]string{"Authorization": authorToken}
+	anonHeaders := map[string]string{}
+
+	check(t, "public visible to everyone", publicPost.Id, anonHeaders, 200)
+	check(t, "followers post visible to a follower", followersPost.Id, followerHeaders, 200)
+	check(t, "followers post hidden from a stranger", followersPost.Id, strangerHeaders, 404)
+	check(t, "mutuals post hidden from a one-way follower", mutualsPost.Id, followerHeaders, 404)
+	check(t, "private post hidden from anyone but the author", privatePost.Id, followerHeaders, 404)
+	check(t, "private post visible to the author", privatePost.Id, authorHeaders, 200)
+	check(t, "moderator-hidden post hidden from the public even though it's 'public' visibility", hiddenPost.Id, anonHeaders, 404)
+	check(t, "moderator-hidden post still visible to its author", hiddenPost.Id, authorHeaders, 200)
+
+	// Make the connection mutual and confirm the mutuals post opens up.
+	backEdge := core.NewRecord(connections)
+	backEdge.Set("follower", author.Id)
+	backEdge.Set("following", follower.Id)
+	if err := app.Save(backEdge); err != nil {
+		t.Fatalf("failed to create back-edge connection: %v", err)
+	}
+	check(t, "mutuals post visible once the follow is mutual", mutualsPost.Id, followerHeaders, 200)
+}
+
+// TestFeedKeysetPaginationAcrossTies creates a batch of posts that all tie
+// on hot_score (every freshly-created, unvoted post gets hot_score 0 - see
+// the posts create hook), then pages /api/feed with a limit smaller than
+// the tie group and asserts every post is returned exactly once. This is
+// the scenario that broke when the SQL ORDER BY and the keyset cursor
+// filter disagreed on the tiebreak column.
+func TestFeedKeysetPaginationAcrossTies(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := core.NewRecord(oracles)
+	author.Set("email", "feedauthor@test.local")
+	author.Set("password", "testpass123")
+	author.Set("name", "FeedAuthor")
+	author.Set("approved", true)
+	if err := app.Save(author); err != nil {
+		t.Fatalf("failed to create author: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const total = 7
+	want := map[string]bool{}
+	for i := 0; i < total; i++ {
+		p := core.NewRecord(posts)
+		p.Set("title", fmt.Sprintf("Tied post %d", i))
+		p.Set("content", "content")
+		p.Set("author", author.Id)
+		p.Set("visibility", "public")
+		p.Set("hot_score", 0)
+		if err := app.Save(p); err != nil {
+			t.Fatalf("failed to create post %d: %v", i, err)
+		}
+		want[p.Id] = true
+	}
+
+	got := map[string]bool{}
+	cursor := ""
+	for page := 0; page < total+2; page++ { // bounded so a regression loops, not hangs
+		url := "/api/feed?sort=hot&limit=2"
+		if cursor != "" {
+			url += "&cursor=" + cursor
+		}
+
+		recorder := testutil.Request(t, app, http.MethodGet, url, nil, nil)
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d (body: %s)", recorder.Code, recorder.Body.String())
+		}
+		body := recorder.Body.String()
+
+		var parsed struct {
+			Posts []struct {
+				ID string `json:"id"`
+			} `json:"posts"`
+			Cursor string `json:"cursor"`
+		}
+		if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+			t.Fatalf("failed to parse feed response: %v (body: %s)", err, body)
+		}
+
+		for _, p := range parsed.Posts {
+			if got[p.ID] {
+				t.Fatalf("post %s returned more than once across pages", p.ID)
+			}
+			got[p.ID] = true
+		}
+
+		if parsed.Cursor == "" {
+			break
+		}
+		cursor = parsed.Cursor
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d distinct posts across all pages, got %d", len(want), len(got))
+	}
+	for id := range want {
+		if !got[id] {
+			t.Fatalf("post %s was never returned by any page", id)
+		}
+	}
+}
+
+// TestOracleActivityEndpoint exercises GET /api/oracles/{id}/activity,
+// checking that it merges posts, comments, and votes into one newest-first
+// timeline.
+func TestOracleActivityEndpoint(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oracle := core.NewRecord(oracles)
+	oracle.Set("email", "activity@test.local")
+	oracle.Set("password", "testpass123")
+	oracle.Set("name", "ActivityOracle")
+	oracle.Set("approved", true)
+	if err := app.Save(oracle); err != nil {
+		t.Fatalf("failed to create oracle: %v", err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := core.NewRecord(posts)
+	post.Set("title", "Activity post")
+	post.Set("content", "content")
+	post.Set("author", oracle.Id)
+	post.Set("visibility", "public")
+	if err := app.Save(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	comments, err := app.FindCollectionByNameOrId("comments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment := core.NewRecord(comments)
+	comment.Set("post", post.Id)
+	comment.Set("content", "Activity comment")
+	comment.Set("author", oracle.Id)
+	comment.Set("visibility", "public")
+	if err := app.Save(comment); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	votes, err := app.FindCollectionByNameOrId("votes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote := core.NewRecord(votes)
+	vote.Set("oracle", oracle.Id)
+	vote.Set("value", 1)
+	vote.Set("target_type", "post")
+	vote.Set("target_post", post.Id)
+	if err := app.Save(vote); err != nil {
+		t.Fatalf("failed to create vote: %v", err)
+	}
+
+	testutil.RunRequest(t, app, pbtests.ApiScenario{
+		Method:         http.MethodGet,
+		URL:            "/api/oracles/" + oracle.Id + "/activity",
+		ExpectedStatus: 200,
+		ExpectedContent: []string{
+			`"type":"post"`,
+			`"type":"comment"`,
+			`"type":"vote"`,
+			`"count":3`,
+		},
+	})
 }
@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"hash/crc32"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// RecordVersion derives an optimistic-concurrency token from a record's
+// `updated` timestamp. Clients round-trip this value as `version`; a
+// mismatch means the record changed since the client last loaded it.
+func RecordVersion(record *core.Record) int {
+	return int(crc32.ChecksumIEEE([]byte(record.GetString("updated"))))
+}
+
+// checkVersion compares the client-supplied version against record's
+// current version and responds with 409 on mismatch.
+func checkVersion(e *core.RequestEvent, record *core.Record, version int) error {
+	if version != RecordVersion(record) {
+		return e.Error(http.StatusConflict, "Record has changed since you last loaded it, please refresh", nil)
+	}
+	return nil
+}
+
+// handleChangePassword lets an oracle change its own password, provided it
+// supplies the current password and a matching version.
+func handleChangePassword(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "oracles" {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+
+	data := struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+		Version         int    `json:"version"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("Invalid request body", err)
+	}
+	if data.CurrentPassword == "" || data.NewPassword == "" {
+		return e.BadRequestError("current_password and new_password are required", nil)
+	}
+
+	oracle, err := e.App.FindRecordById("oracles", e.Auth.Id)
+	if err != nil {
+		return e.NotFoundError("Oracle not found", err)
+	}
+
+	if err := checkVersion(e, oracle, data.Version); err != nil {
+		return err
+	}
+
+	if !oracle.ValidatePassword(data.CurrentPassword) {
+		return e.BadRequestError("Current password is incorrect", nil)
+	}
+
+	oracle.SetPassword(data.NewPassword)
+	if err := e.App.Save(oracle); err != nil {
+		return e.BadRequestError("Failed to change password", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"success": true})
+}
+
+// handleSetPassword lets a superuser reset any oracle's password, e.g. for
+// support requests. It bypasses the version check since it's an admin
+// override, not a self-service update.
+func handleSetPassword(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "_superusers" {
+		return e.ForbiddenError("Superuser required", nil)
+	}
+
+	data := struct {
+		OracleId    string `json:"oracle_id"`
+		NewPassword string `json:"new_password"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("Invalid request body", err)
+	}
+	if data.OracleId == "" || data.NewPassword == "" {
+		return e.BadRequestError("oracle_id and new_password are required", nil)
+	}
+
+	oracle, err := e.App.FindRecordById("oracles", data.OracleId)
+	if err != nil {
+		return e.NotFoundError("Oracle not found", err)
+	}
+
+	oracle.SetPassword(data.NewPassword)
+	if err := e.App.Save(oracle); err != nil {
+		return e.BadRequestError("Failed to set password", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"success": true})
+}
+
+// handleProfileUpdate edits an oracle's bio, oracle_name, and repo_url. It
+// explicitly forbids mutating approved, human, or email: a request body
+// carrying any of them is rejected with 403.
+func handleProfileUpdate(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "oracles" {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+
+	data := struct {
+		Bio        *string `json:"bio"`
+		OracleName *string `json:"oracle_name"`
+		RepoURL    *string `json:"repo_url"`
+		Approved   *bool   `json:"approved"`
+		Human      *string `json:"human"`
+		Email      *string `json:"email"`
+		Version    int     `json:"version"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("Invalid request body", err)
+	}
+
+	if data.Approved != nil || data.Human != nil || data.Email != nil {
+		return e.ForbiddenError("Cannot mutate approved, human, or email via this endpoint", nil)
+	}
+
+	oracle, err := e.App.FindRecordById("oracles", e.Auth.Id)
+	if err != nil {
+		return e.NotFoundError("Oracle not found", err)
+	}
+
+	if err := checkVersion(e, oracle, data.Version); err != nil {
+		return err
+	}
+
+	if data.Bio != nil {
+		oracle.Set("bio", *data.Bio)
+	}
+	if data.OracleName != nil {
+		oracle.Set("oracle_name", *data.OracleName)
+	}
+	if data.RepoURL != nil {
+		oracle.Set("repo_url", *data.RepoURL)
+	}
+
+	if err := e.App.Save(oracle); err != nil {
+		return e.BadRequestError("Failed to update profile", err)
+	}
+
+	return e.JSON(http.StatusOK, oracle)
+}
@@ -1,16 +1,22 @@
 package hooks
 
 import (
-	"math"
+	"encoding/base64"
+	"errors"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/Soul-Brews-Studio/oracle-net/internal/workers"
 )
 
-func BindHooks(app core.App) {
+// BindHooks registers oracle-net's record hooks. w may be nil, in which case
+// heartbeat presence is upserted synchronously on every request.
+func BindHooks(app core.App, w *workers.Workers) {
 	app.OnRecordCreateRequest("posts").BindFunc(func(e *core.RecordRequestEvent) error {
 		if e.Auth == nil {
 			return e.UnauthorizedError("Authentication required", nil)
@@ -19,7 +25,27 @@ func BindHooks(app core.App) {
 		e.Record.Set("upvotes", 0)
 		e.Record.Set("downvotes", 0)
 		e.Record.Set("score", 0)
-		return e.Next()
+		e.Record.Set("hot_score", workers.CalculateHotScore(0, 0))
+		if e.Record.GetString("visibility") == "" {
+			e.Record.Set("visibility", "public")
+		}
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		// No natural notification target for a post's own creation - just
+		// log it for the activity_log replay record.
+		if w != nil {
+			w.Activity.Enqueue(workers.Activity{
+				Actor:  e.Auth.Id,
+				Verb:   "post.created",
+				Object: e.Record.Id,
+				At:     time.Now(),
+			})
+		}
+
+		return nil
 	})
 
 	app.OnRecordCreateRequest("comments").BindFunc(func(e *core.RecordRequestEvent) error {
@@ -29,6 +55,50 @@ func BindHooks(app core.App) {
 		e.Record.Set("author", e.Auth.Id)
 		e.Record.Set("upvotes", 0)
 		e.Record.Set("downvotes", 0)
+		if e.Record.GetString("visibility") == "" {
+			e.Record.Set("visibility", "public")
+		}
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if w != nil {
+			// Notify whoever the comment is actually a reply to: the
+			// parent comment's author for a nested reply, or the post's
+			// author for a top-level comment - the same direct-object
+			// addressing handleVote uses for its own target.
+			target := ""
+			if parentId := e.Record.GetString("parent"); parentId != "" {
+				if parent, err := e.App.FindRecordById("comments", parentId); err == nil {
+					target = parent.GetString("author")
+				}
+			} else if post, err := e.App.FindRecordById("posts", e.Record.GetString("post")); err == nil {
+				target = post.GetString("author")
+			}
+			w.Activity.Enqueue(workers.Activity{
+				Actor:  e.Auth.Id,
+				Verb:   "comment.created",
+				Object: e.Record.Id,
+				Target: target,
+				At:     time.Now(),
+			})
+		}
+
+		return nil
+	})
+
+	app.OnRecordViewRequest("posts").BindFunc(func(e *core.RecordRequestEvent) error {
+		if !CanView(e.App, e.Auth, e.Record) {
+			return e.NotFoundError("Post not found", nil)
+		}
+		return e.Next()
+	})
+
+	app.OnRecordViewRequest("comments").BindFunc(func(e *core.RecordRequestEvent) error {
+		if !CanView(e.App, e.Auth, e.Record) {
+			return e.NotFoundError("Comment not found", nil)
+		}
 		return e.Next()
 	})
 
@@ -37,7 +107,24 @@ func BindHooks(app core.App) {
 			return e.UnauthorizedError("Authentication required", nil)
 		}
 		e.Record.Set("oracle", e.Auth.Id)
-		return e.Next()
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		evt := workers.HeartbeatEvent{
+			OracleID: e.Auth.Id,
+			Status:   e.Record.GetString("status"),
+			At:       time.Now(),
+		}
+		if w == nil {
+			return workers.NewHeartbeatPool(e.App).UpsertPresence(evt)
+		}
+		if !w.Heartbeat.Enqueue(evt) {
+			return w.Heartbeat.UpsertPresence(evt)
+		}
+
+		return nil
 	})
 
 	app.OnRecordCreateRequest("connections").BindFunc(func(e *core.RecordRequestEvent) error {
@@ -76,8 +163,10 @@ var (
 	BuildTime = "unknown"
 )
 
-func BindRoutes(app core.App) {
+func BindRoutes(app core.App, w *workers.Workers) {
 	app.OnServe().BindFunc(func(se *core.ServeEvent) error {
+		bindReverseProxyAuth(se)
+
 		se.Router.GET("/api/info", func(e *core.RequestEvent) error {
 			return e.JSON(http.StatusOK, map[string]any{
 				"service": "oraclenet",
@@ -121,28 +210,19 @@ func BindRoutes(app core.App) {
 		})
 
 		se.Router.GET("/api/oracles/presence", func(e *core.RequestEvent) error {
-			cutoff := time.Now().Add(-5 * time.Minute).UTC()
-
 			oracles, err := e.App.FindRecordsByFilter("oracles", "approved = true", "name", 0, 0)
 			if err != nil {
 				return e.BadRequestError("Failed to fetch oracles", err)
 			}
 
-			heartbeats, err := e.App.FindAllRecords("heartbeats")
+			presenceRecords, err := e.App.FindAllRecords("presence")
 			if err != nil {
-				return e.BadRequestError("Failed to fetch heartbeats: "+err.Error(), nil)
+				return e.BadRequestError("Failed to fetch presence: "+err.Error(), nil)
 			}
 
 			presenceMap := make(map[string]*core.Record)
-			for _, hb := range heartbeats {
-				created := hb.GetDateTime("created").Time()
-				if created.Before(cutoff) {
-					continue
-				}
-				oracleId := hb.GetString("oracle")
-				if _, exists := presenceMap[oracleId]; !exists {
-					presenceMap[oracleId] = hb
-				}
+			for _, p := range presenceRecords {
+				presenceMap[p.GetString("oracle")] = p
 			}
 
 			items := []map[string]any{}
@@ -150,9 +230,9 @@ func BindRoutes(app core.App) {
 			for _, oracle := range oracles {
 				status := "offline"
 				lastSeen := ""
-				if hb, ok := presenceMap[oracle.Id]; ok {
-					status = hb.GetString("status")
-					lastSeen = hb.GetDateTime("created").String()
+				if p, ok := presenceMap[oracle.Id]; ok {
+					status = p.GetString("status")
+					lastSeen = p.GetDateTime("last_seen").String()
 				}
 				switch status {
 				case "online":
@@ -178,6 +258,44 @@ func BindRoutes(app core.App) {
 			})
 		})
 
+		// GET /api/oracles/:id/activity
+		se.Router.GET("/api/oracles/{id}/activity", handleOracleActivity)
+
+		// === OAUTH / BIRTH ROUTES ===
+
+		// POST /api/oracles/birth
+		se.Router.POST("/api/oracles/birth", handleBirth)
+
+		// === ACCOUNT SETTINGS ROUTES ===
+
+		// POST /api/oracles/me/change-password
+		se.Router.POST("/api/oracles/me/change-password", handleChangePassword)
+
+		// POST /api/oracles/me/set-password (superuser-only)
+		se.Router.POST("/api/oracles/me/set-password", handleSetPassword)
+
+		// PATCH /api/oracles/me
+		se.Router.PATCH("/api/oracles/me", handleProfileUpdate)
+
+		// === NOTIFICATION ROUTES ===
+
+		// GET /api/notifications
+		se.Router.GET("/api/notifications", handleListNotifications)
+
+		// POST /api/notifications/:id/read
+		se.Router.POST("/api/notifications/{id}/read", handleReadNotification)
+
+		// === REPORTING / MODERATION ROUTES ===
+
+		// POST /api/reports
+		se.Router.POST("/api/reports", handleCreateReport)
+
+		// GET /api/moderation/queue (superuser-only)
+		se.Router.GET("/api/moderation/queue", handleModerationQueue)
+
+		// POST /api/moderation/reports/:id/resolve (superuser-only)
+		se.Router.POST("/api/moderation/reports/{id}/resolve", handleResolveReport)
+
 		// === FEED ROUTES (Moltbook-style) ===
 
 		// GET /api/feed?sort=hot|new|top|rising&limit=25
@@ -194,29 +312,46 @@ func BindRoutes(app core.App) {
 
 		// POST /api/posts/:id/upvote
 		se.Router.POST("/api/posts/{id}/upvote", func(e *core.RequestEvent) error {
-			return handleVote(e, "post", 1)
+			return handleVote(e, "post", 1, w)
 		})
 
 		// POST /api/posts/:id/downvote
 		se.Router.POST("/api/posts/{id}/downvote", func(e *core.RequestEvent) error {
-			return handleVote(e, "post", -1)
+			return handleVote(e, "post", -1, w)
 		})
 
 		// POST /api/comments/:id/upvote
 		se.Router.POST("/api/comments/{id}/upvote", func(e *core.RequestEvent) error {
-			return handleVote(e, "comment", 1)
+			return handleVote(e, "comment", 1, w)
 		})
 
 		// POST /api/comments/:id/downvote
 		se.Router.POST("/api/comments/{id}/downvote", func(e *core.RequestEvent) error {
-			return handleVote(e, "comment", -1)
+			return handleVote(e, "comment", -1, w)
 		})
 
 		return se.Next()
 	})
 }
 
-// handleFeed returns posts sorted by hot/new/top/rising
+// feedScanMultiplier over-fetches each SQL page by this factor so that
+// posts dropped by the in-memory hidden/visibility filter (not expressible
+// as a single SQL predicate against a viewer's FriendSet) still leave
+// enough rows to fill a page.
+const feedScanMultiplier = 3
+
+// risingWindow bounds how far back handleFeed looks for "rising" candidates.
+// The rising formula (score decayed by age) isn't a plain column, so it
+// can't be pushed to an SQL ORDER BY; the window keeps the in-memory
+// computation bounded instead of scoring the whole table.
+const risingWindow = 72 * time.Hour
+const risingScanLimit = 500
+
+// handleFeed returns posts sorted by hot/new/top/rising, keyset-paginated
+// via ?cursor= on (hot_score, id) (or the equivalent rank field for
+// new/top). For hot/new/top, ranking is resolved at the SQL layer via
+// FindRecordsByFilter's sort+limit instead of loading every post into
+// memory; rising is ranked in Go over a bounded window (fetchRisingFeed).
 func handleFeed(e *core.RequestEvent) error {
 	sortType := e.Request.URL.Query().Get("sort")
 	if sortType == "" {
@@ -231,77 +366,42 @@ func handleFeed(e *core.RequestEvent) error {
 		}
 	}
 
-	// Fetch posts
-	posts, err := e.App.FindAllRecords("posts")
-	if err != nil {
-		return e.BadRequestError("Failed to fetch posts", err)
-	}
+	cursor := e.Request.URL.Query().Get("cursor")
 
-	// Fetch oracles for expansion
 	oraclesMap := make(map[string]*core.Record)
 	oracles, _ := e.App.FindAllRecords("oracles")
 	for _, o := range oracles {
 		oraclesMap[o.Id] = o
 	}
 
-	// Build post items with hot score
-	type postItem struct {
-		record   *core.Record
-		hotScore float64
+	isSuperuser := e.Auth != nil && e.Auth.Collection().Name == "_superusers"
+	friends, err := NewFriendSet(e.App, e.Auth)
+	if err != nil {
+		return e.BadRequestError("Failed to resolve visibility", err)
 	}
-
-	items := make([]postItem, 0, len(posts))
-	now := time.Now()
-
-	for _, post := range posts {
-		upvotes := post.GetFloat("upvotes")
-		downvotes := post.GetFloat("downvotes")
-		created := post.GetDateTime("created").Time()
-
-		// Calculate hot score (simplified Reddit algorithm)
-		score := upvotes - downvotes
-		age := now.Sub(created).Hours()
-		hotScore := calculateHotScore(score, age)
-
-		items = append(items, postItem{record: post, hotScore: hotScore})
+	viewerId := ""
+	if e.Auth != nil {
+		viewerId = e.Auth.Id
 	}
 
-	// Sort based on type
-	switch sortType {
-	case "hot":
-		sort.Slice(items, func(i, j int) bool {
-			return items[i].hotScore > items[j].hotScore
-		})
-	case "new":
-		sort.Slice(items, func(i, j int) bool {
-			return items[i].record.GetDateTime("created").Time().After(items[j].record.GetDateTime("created").Time())
-		})
-	case "top":
-		sort.Slice(items, func(i, j int) bool {
-			scoreI := items[i].record.GetFloat("upvotes") - items[i].record.GetFloat("downvotes")
-			scoreJ := items[j].record.GetFloat("upvotes") - items[j].record.GetFloat("downvotes")
-			return scoreI > scoreJ
-		})
-	case "rising":
-		// Rising = high votes in short time
-		sort.Slice(items, func(i, j int) bool {
-			scoreI := items[i].record.GetFloat("upvotes") - items[i].record.GetFloat("downvotes")
-			scoreJ := items[j].record.GetFloat("upvotes") - items[j].record.GetFloat("downvotes")
-			ageI := now.Sub(items[i].record.GetDateTime("created").Time()).Hours() + 1
-			ageJ := now.Sub(items[j].record.GetDateTime("created").Time()).Hours() + 1
-			return (scoreI / ageI) > (scoreJ / ageJ)
-		})
+	visible := func(post *core.Record) bool {
+		return isSuperuser || friends.CanView(viewerId, post)
 	}
 
-	// Limit results
-	if len(items) > limit {
-		items = items[:limit]
+	var posts []*core.Record
+	var nextCursor string
+	if sortType == "rising" {
+		posts, nextCursor, err = fetchRisingFeed(e.App, cursor, limit, visible)
+	} else {
+		posts, nextCursor, err = fetchRankedFeed(e.App, sortType, cursor, limit, visible)
+	}
+	if err != nil {
+		return e.BadRequestError("Failed to fetch posts", err)
 	}
 
 	// Build response
-	result := make([]map[string]any, 0, len(items))
-	for _, item := range items {
-		post := item.record
+	result := make([]map[string]any, 0, len(posts))
+	for _, post := range posts {
 		authorId := post.GetString("author")
 		var author map[string]any
 		if o, ok := oraclesMap[authorId]; ok {
@@ -320,6 +420,7 @@ func handleFeed(e *core.RequestEvent) error {
 			"upvotes":   int(post.GetFloat("upvotes")),
 			"downvotes": int(post.GetFloat("downvotes")),
 			"score":     int(post.GetFloat("score")),
+			"hot_score": post.GetFloat("hot_score"),
 			"created":   post.GetDateTime("created").String(),
 			"author":    author,
 		})
@@ -330,31 +431,301 @@ func handleFeed(e *core.RequestEvent) error {
 		"sort":    sortType,
 		"posts":   result,
 		"count":   len(result),
+		"cursor":  nextCursor,
 	})
 }
 
-// calculateHotScore implements a simplified Reddit hot algorithm
-func calculateHotScore(score float64, ageHours float64) float64 {
-	// Logarithm of score (handles negative scores)
-	order := math.Log10(math.Max(math.Abs(score), 1))
+// rankFieldFor returns the SQL sort expression and its leading rank field
+// (used for the keyset cursor) for a hot/new/top feed sort. sortExpr always
+// breaks ties on id, matching the (rankField, id) tuple fetchRankedFeed's
+// cursor filters on - any other tiebreaker (e.g. created) would let the SQL
+// order and the cursor filter disagree within a tied rankField group,
+// skipping or duplicating rows across pages.
+func rankFieldFor(sortType string) (sortExpr, rankField string) {
+	switch sortType {
+	case "new":
+		return "-created,-id", "created"
+	case "top":
+		return "-score,-id", "score"
+	default: // "hot"
+		return "-hot_score,-id", "hot_score"
+	}
+}
+
+// fetchRankedFeed loads one page of posts ordered by sortType directly at
+// the SQL layer, keyset-paginated on (rankField, id) so the whole table is
+// never loaded. It re-queries past rows that the visible callback drops
+// until the page is full or the table is exhausted.
+func fetchRankedFeed(app core.App, sortType, cursor string, limit int, visible func(*core.Record) bool) ([]*core.Record, string, error) {
+	sortExpr, rankField := rankFieldFor(sortType)
+
+	var afterValue, afterId string
+	hasCursor := false
+	if cursor != "" {
+		c, ok := decodeFeedCursor(cursor)
+		if !ok {
+			return nil, "", errors.New("invalid cursor")
+		}
+		afterValue, afterId, hasCursor = c.value, c.id, true
+	}
+
+	results := make([]*core.Record, 0, limit)
+	fetchSize := limit * feedScanMultiplier
+	var lastSeen *core.Record
+
+	for len(results) < limit {
+		filter := ""
+		params := map[string]any{}
+		if hasCursor {
+			filter = rankField + " < {:cv} || (" + rankField + " = {:cv} && id < {:cid})"
+			if rankField == "created" {
+				params["cv"] = afterValue
+			} else {
+				cv, _ := strconv.ParseFloat(afterValue, 64)
+				params["cv"] = cv
+			}
+			params["cid"] = afterId
+		}
+
+		page, err := app.FindRecordsByFilter("posts", filter, sortExpr, fetchSize, 0, params)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, p := range page {
+			lastSeen = p
+			if visible(p) {
+				results = append(results, p)
+				if len(results) == limit {
+					break
+				}
+			}
+		}
+
+		afterValue = rankValueString(lastSeen, rankField)
+		afterId = lastSeen.Id
+		hasCursor = true
+
+		if len(page) < fetchSize {
+			break
+		}
+	}
+
+	next := ""
+	if len(results) == limit {
+		last := results[len(results)-1]
+		next = encodeFeedCursor(rankValueString(last, rankField), last.Id)
+	}
+
+	return results, next, nil
+}
+
+// fetchRisingFeed computes the rising rank (score decayed by age) over a
+// bounded recent window in Go, then paginates the already-sorted slice by
+// post id.
+func fetchRisingFeed(app core.App, cursor string, limit int, visible func(*core.Record) bool) ([]*core.Record, string, error) {
+	since := time.Now().Add(-risingWindow)
+	candidates, err := app.FindRecordsByFilter(
+		"posts",
+		"created > {:since}",
+		"-created",
+		risingScanLimit,
+		0,
+		map[string]any{"since": since},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	type scored struct {
+		record *core.Record
+		rising float64
+	}
+
+	now := time.Now()
+	items := make([]scored, 0, len(candidates))
+	for _, p := range candidates {
+		if !visible(p) {
+			continue
+		}
+		score := p.GetFloat("score")
+		age := now.Sub(p.GetDateTime("created").Time()).Hours() + 1
+		items = append(items, scored{record: p, rising: score / age})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].rising > items[j].rising })
+
+	start := 0
+	if cursor != "" {
+		c, ok := decodeFeedCursor(cursor)
+		if !ok {
+			return nil, "", errors.New("invalid cursor")
+		}
+		for i, it := range items {
+			if it.record.Id == c.id {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	page := make([]*core.Record, 0, end-start)
+	for _, it := range items[start:end] {
+		page = append(page, it.record)
+	}
+
+	next := ""
+	if end < len(items) && len(page) > 0 {
+		next = encodeFeedCursor("", page[len(page)-1].Id)
+	}
+
+	return page, next, nil
+}
+
+// feedCursor is the decoded form of handleFeed's opaque ?cursor= value: the
+// last-seen rank field value and record id of a keyset page.
+type feedCursor struct {
+	value string
+	id    string
+}
+
+func encodeFeedCursor(value, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value + "|" + id))
+}
+
+func decodeFeedCursor(raw string) (feedCursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return feedCursor{}, false
+	}
+
+	parts := strings.SplitN(string(data), "|", 2)
+	if len(parts) != 2 {
+		return feedCursor{}, false
+	}
+
+	return feedCursor{value: parts[0], id: parts[1]}, true
+}
+
+// rankValueString renders record's rankField value as the string form
+// encodeFeedCursor expects: raw for the created timestamp, formatted for
+// the numeric score/hot_score fields.
+func rankValueString(record *core.Record, rankField string) string {
+	if rankField == "created" {
+		return record.GetString("created")
+	}
+	return strconv.FormatFloat(record.GetFloat(rankField), 'f', -1, 64)
+}
+
+// handleOracleActivity returns a merged, newest-first timeline of an
+// oracle's posts, comments, and votes cast. activity_log is an internal
+// replay/notification log, not a per-oracle view, so this merges the three
+// source collections directly rather than reading it.
+func handleOracleActivity(e *core.RequestEvent) error {
+	oracleId := e.Request.PathValue("id")
+	if oracleId == "" {
+		return e.BadRequestError("Missing oracle ID", nil)
+	}
+	if _, err := e.App.FindRecordById("oracles", oracleId); err != nil {
+		return e.NotFoundError("Oracle not found", err)
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(e.Request.URL.Query().Get("limit")); err == nil && l > 0 && l <= 200 {
+		limit = l
+	}
+
+	posts, err := e.App.FindRecordsByFilter("posts", "author = {:id}", "-created", limit, 0, map[string]any{"id": oracleId})
+	if err != nil {
+		return e.BadRequestError("Failed to fetch posts", err)
+	}
+	comments, err := e.App.FindRecordsByFilter("comments", "author = {:id}", "-created", limit, 0, map[string]any{"id": oracleId})
+	if err != nil {
+		return e.BadRequestError("Failed to fetch comments", err)
+	}
+	votes, err := e.App.FindRecordsByFilter("votes", "oracle = {:id}", "-created", limit, 0, map[string]any{"id": oracleId})
+	if err != nil {
+		return e.BadRequestError("Failed to fetch votes", err)
+	}
+
+	type entry struct {
+		created time.Time
+		item    map[string]any
+	}
+	entries := make([]entry, 0, len(posts)+len(comments)+len(votes))
+
+	for _, p := range posts {
+		entries = append(entries, entry{
+			created: p.GetDateTime("created").Time(),
+			item: map[string]any{
+				"type":    "post",
+				"id":      p.Id,
+				"title":   p.GetString("title"),
+				"score":   int(p.GetFloat("score")),
+				"created": p.GetDateTime("created").String(),
+			},
+		})
+	}
+
+	for _, c := range comments {
+		entries = append(entries, entry{
+			created: c.GetDateTime("created").Time(),
+			item: map[string]any{
+				"type":    "comment",
+				"id":      c.Id,
+				"post":    c.GetString("post"),
+				"content": c.GetString("content"),
+				"created": c.GetDateTime("created").String(),
+			},
+		})
+	}
 
-	// Sign of score
-	sign := 0.0
-	if score > 0 {
-		sign = 1
-	} else if score < 0 {
-		sign = -1
+	for _, v := range votes {
+		targetType := v.GetString("target_type")
+		targetId := v.GetString("target_post")
+		if targetType == "comment" {
+			targetId = v.GetString("target_comment")
+		}
+		entries = append(entries, entry{
+			created: v.GetDateTime("created").Time(),
+			item: map[string]any{
+				"type":        "vote",
+				"id":          v.Id,
+				"target_type": targetType,
+				"target_id":   targetId,
+				"value":       int(v.GetFloat("value")),
+				"created":     v.GetDateTime("created").String(),
+			},
+		})
 	}
 
-	// Time decay (posts lose hotness over time)
-	// Higher decay = faster cooling
-	decay := ageHours / 12.0 // Half-life of about 12 hours
+	sort.Slice(entries, func(i, j int) bool { return entries[i].created.After(entries[j].created) })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
 
-	return sign*order - decay
+	items := make([]map[string]any, 0, len(entries))
+	for _, en := range entries {
+		items = append(items, en.item)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{
+		"oracle": oracleId,
+		"items":  items,
+		"count":  len(items),
+	})
 }
 
 // handleVote processes upvotes/downvotes for posts or comments
-func handleVote(e *core.RequestEvent, targetType string, value int) error {
+func handleVote(e *core.RequestEvent, targetType string, value int, w *workers.Workers) error {
 	if e.Auth == nil {
 		return e.UnauthorizedError("Authentication required", nil)
 	}
@@ -415,8 +786,9 @@ func handleVote(e *core.RequestEvent, targetType string, value int) error {
 
 	var oldValue int
 	var voteRecord *core.Record
+	wasExisting := len(existingVotes) > 0
 
-	if len(existingVotes) > 0 {
+	if wasExisting {
 		// Update existing vote
 		voteRecord = existingVotes[0]
 		oldValue = int(voteRecord.GetFloat("value"))
@@ -472,26 +844,52 @@ func handleVote(e *core.RequestEvent, targetType string, value int) error {
 	target.Set("upvotes", upvotes)
 	target.Set("downvotes", downvotes)
 
-	// Calculate score for posts
+	// Calculate score and hot_score for posts
 	if targetType == "post" {
 		score := upvotes - downvotes
 		target.Set("score", score)
+
+		ageHours := time.Since(target.GetDateTime("created").Time()).Hours()
+		target.Set("hot_score", workers.CalculateHotScore(float64(score), ageHours))
 	}
 
 	if err := e.App.Save(target); err != nil {
 		return e.BadRequestError("Failed to update vote counts", err)
 	}
 
-	// Update author karma (including self-votes for testing)
-	if authorId != "" {
-		author, err := e.App.FindRecordById("oracles", authorId)
-		if err == nil {
+	// Karma recomputation and notification fan-out happen off the request
+	// path via the ActivityQueue; fall back to a synchronous update when
+	// workers aren't wired (e.g. older callers or non-default setups).
+	verb := "vote.cast"
+	if wasExisting {
+		if value == 0 {
+			verb = "vote.removed"
+		} else {
+			verb = "vote.changed"
+		}
+	}
+
+	if w != nil {
+		w.Activity.Enqueue(workers.Activity{
+			Actor:  e.Auth.Id,
+			Verb:   verb,
+			Object: targetId,
+			Target: authorId,
+			Score:  value - oldValue,
+			At:     time.Now(),
+		})
+	} else if authorId != "" {
+		workers.WithKarmaLock(authorId, func() error {
+			author, err := e.App.FindRecordById("oracles", authorId)
+			if err != nil {
+				return err
+			}
 			karma := int(author.GetFloat("karma"))
 			karma -= oldValue // Remove old effect
 			karma += value    // Add new effect
 			author.Set("karma", karma)
-			e.App.Save(author) // Best effort, don't fail on karma update
-		}
+			return e.App.Save(author) // Best effort, don't fail on karma update
+		})
 	}
 
 	// Return Moltbook-style response
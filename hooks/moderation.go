@@ -0,0 +1,265 @@
+package hooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/Soul-Brews-Studio/oracle-net/internal/workers"
+)
+
+// reportTarget resolves a report's polymorphic target_type into the
+// collection name and record id it points at.
+func reportTarget(report *core.Record) (collectionName, targetId string) {
+	switch report.GetString("target_type") {
+	case "post":
+		return "posts", report.GetString("target_post")
+	case "comment":
+		return "comments", report.GetString("target_comment")
+	case "oracle":
+		return "oracles", report.GetString("target_oracle")
+	default:
+		return "", ""
+	}
+}
+
+// handleCreateReport files a report against a post, comment, or oracle. It
+// mirrors handleVote's auth+approval checks and enforces one open report
+// per (reporter, target).
+func handleCreateReport(e *core.RequestEvent) error {
+	if e.Auth == nil {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+	if !e.Auth.GetBool("approved") {
+		return e.ForbiddenError("You must be approved to report", nil)
+	}
+
+	data := struct {
+		TargetType    string `json:"target_type"`
+		TargetPost    string `json:"target_post"`
+		TargetComment string `json:"target_comment"`
+		TargetOracle  string `json:"target_oracle"`
+		Reason        string `json:"reason"`
+		Details       string `json:"details"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("Invalid request body", err)
+	}
+
+	var targetId string
+	switch data.TargetType {
+	case "post":
+		targetId = data.TargetPost
+	case "comment":
+		targetId = data.TargetComment
+	case "oracle":
+		targetId = data.TargetOracle
+	default:
+		return e.BadRequestError("target_type must be post, comment, or oracle", nil)
+	}
+	if targetId == "" {
+		return e.BadRequestError("Missing target ID", nil)
+	}
+
+	existing, err := e.App.FindRecordsByFilter(
+		"reports",
+		"reporter = {:reporter} && target_type = {:type} && status = 'open' && (target_post = {:id} || target_comment = {:id} || target_oracle = {:id})",
+		"",
+		1,
+		0,
+		map[string]any{"reporter": e.Auth.Id, "type": data.TargetType, "id": targetId},
+	)
+	if err != nil {
+		return e.BadRequestError("Failed to check existing reports", err)
+	}
+	if len(existing) > 0 {
+		return e.BadRequestError("You already have an open report for this target", nil)
+	}
+
+	collection, err := e.App.FindCollectionByNameOrId("reports")
+	if err != nil {
+		return e.BadRequestError("Reports collection not found", err)
+	}
+
+	report := core.NewRecord(collection)
+	report.Set("reporter", e.Auth.Id)
+	report.Set("target_type", data.TargetType)
+	report.Set("target_post", data.TargetPost)
+	report.Set("target_comment", data.TargetComment)
+	report.Set("target_oracle", data.TargetOracle)
+	report.Set("reason", data.Reason)
+	report.Set("details", data.Details)
+	report.Set("status", "open")
+
+	if err := e.App.Save(report); err != nil {
+		return e.BadRequestError("Failed to save report", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"success": true, "id": report.Id})
+}
+
+// handleModerationQueue returns paginated open reports with the expanded
+// target, restricted to superusers.
+func handleModerationQueue(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "_superusers" {
+		return e.ForbiddenError("Superuser required", nil)
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(e.Request.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	perPage := 25
+	if pp, err := strconv.Atoi(e.Request.URL.Query().Get("perPage")); err == nil && pp > 0 && pp <= 100 {
+		perPage = pp
+	}
+
+	reports, err := e.App.FindRecordsByFilter("reports", "status = 'open'", "-created", perPage, (page-1)*perPage)
+	if err != nil {
+		return e.BadRequestError("Failed to fetch reports", err)
+	}
+
+	items := make([]map[string]any, 0, len(reports))
+	for _, report := range reports {
+		collectionName, targetId := reportTarget(report)
+
+		var target *core.Record
+		if targetId != "" {
+			target, _ = e.App.FindRecordById(collectionName, targetId)
+		}
+
+		items = append(items, map[string]any{
+			"id":          report.Id,
+			"reporter":    report.GetString("reporter"),
+			"target_type": report.GetString("target_type"),
+			"reason":      report.GetString("reason"),
+			"details":     report.GetString("details"),
+			"status":      report.GetString("status"),
+			"target":      target,
+		})
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"items": items, "page": page, "perPage": perPage})
+}
+
+// handleResolveReport applies a moderation action to a report's target:
+// hide (sets `hidden`), remove (hides and deducts karma proportional to
+// accumulated upvotes), ban_author (unapproves the target's author), or
+// dismiss (no target mutation).
+func handleResolveReport(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "_superusers" {
+		return e.ForbiddenError("Superuser required", nil)
+	}
+
+	data := struct {
+		Action string `json:"action"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("Invalid request body", err)
+	}
+
+	report, err := e.App.FindRecordById("reports", e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("Report not found", err)
+	}
+
+	collectionName, targetId := reportTarget(report)
+	if collectionName == "" {
+		return e.BadRequestError("Report has an invalid target_type", nil)
+	}
+
+	var target *core.Record
+	if targetId != "" {
+		target, err = e.App.FindRecordById(collectionName, targetId)
+		if err != nil {
+			return e.NotFoundError("Report target not found", err)
+		}
+	}
+
+	switch data.Action {
+	case "hide":
+		if target == nil {
+			return e.BadRequestError("Cannot hide a report with no target", nil)
+		}
+		target.Set("hidden", true)
+		if err := e.App.Save(target); err != nil {
+			return e.BadRequestError("Failed to hide target", err)
+		}
+	case "remove":
+		if target == nil {
+			return e.BadRequestError("Cannot remove a report with no target", nil)
+		}
+		target.Set("hidden", true)
+		if err := e.App.Save(target); err != nil {
+			return e.BadRequestError("Failed to hide target", err)
+		}
+		if err := deductKarmaForRemoval(e.App, collectionName, target); err != nil {
+			return e.BadRequestError("Failed to deduct karma", err)
+		}
+	case "ban_author":
+		authorId := reportTargetAuthor(collectionName, targetId, target)
+		if authorId == "" {
+			return e.BadRequestError("Report target has no author to ban", nil)
+		}
+		author, err := e.App.FindRecordById("oracles", authorId)
+		if err != nil {
+			return e.NotFoundError("Author not found", err)
+		}
+		author.Set("approved", false)
+		if err := e.App.Save(author); err != nil {
+			return e.BadRequestError("Failed to ban author", err)
+		}
+	case "dismiss":
+		// no target mutation
+	default:
+		return e.BadRequestError("action must be hide, remove, ban_author, or dismiss", nil)
+	}
+
+	report.Set("status", "resolved")
+	report.Set("resolver", e.Auth.Id)
+	if err := e.App.Save(report); err != nil {
+		return e.BadRequestError("Failed to resolve report", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"success": true})
+}
+
+func reportTargetAuthor(collectionName, targetId string, target *core.Record) string {
+	if collectionName == "oracles" {
+		return targetId
+	}
+	if target == nil {
+		return ""
+	}
+	return target.GetString("author")
+}
+
+// deductKarmaForRemoval removes karma proportional to the accumulated
+// upvotes on a removed post/comment. Oracles have no upvotes of their own
+// and are skipped. Goes through workers.WithKarmaLock since this runs on
+// its own request goroutine, independently of ActivityQueue's workers, and
+// both do an unsynchronized read-modify-write on the same author's karma.
+func deductKarmaForRemoval(app core.App, collectionName string, target *core.Record) error {
+	if collectionName == "oracles" {
+		return nil
+	}
+
+	authorId := target.GetString("author")
+	if authorId == "" {
+		return nil
+	}
+
+	upvotes := int(target.GetFloat("upvotes"))
+
+	return workers.WithKarmaLock(authorId, func() error {
+		author, err := app.FindRecordById("oracles", authorId)
+		if err != nil {
+			return err
+		}
+
+		author.Set("karma", int(author.GetFloat("karma"))-upvotes)
+
+		return app.Save(author)
+	})
+}
@@ -0,0 +1,52 @@
+package hooks
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// handleListNotifications returns the authenticated oracle's notifications,
+// newest first.
+func handleListNotifications(e *core.RequestEvent) error {
+	if e.Auth == nil {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+
+	notifications, err := e.App.FindRecordsByFilter(
+		"notifications",
+		"oracle = {:id}",
+		"-created",
+		0,
+		0,
+		map[string]any{"id": e.Auth.Id},
+	)
+	if err != nil {
+		return e.BadRequestError("Failed to fetch notifications", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"items": notifications})
+}
+
+// handleReadNotification marks a single notification as read.
+func handleReadNotification(e *core.RequestEvent) error {
+	if e.Auth == nil {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+
+	notification, err := e.App.FindRecordById("notifications", e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("Notification not found", err)
+	}
+
+	if notification.GetString("oracle") != e.Auth.Id {
+		return e.ForbiddenError("Not your notification", nil)
+	}
+
+	notification.Set("read", true)
+	if err := e.App.Save(notification); err != nil {
+		return e.BadRequestError("Failed to mark notification read", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"success": true})
+}
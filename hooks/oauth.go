@@ -0,0 +1,203 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/auth"
+	"golang.org/x/oauth2"
+)
+
+// GithubTokenURL and GithubUserURL back the GitHub auth.Provider built by
+// githubProvider, and GithubAPIBaseURL backs the birth-issue lookup below.
+// Exposed as vars (rather than consts) so tests can point them at a fake
+// server.
+var (
+	GithubTokenURL   = "https://github.com/login/oauth/access_token"
+	GithubUserURL    = "https://api.github.com/user"
+	GithubAPIBaseURL = "https://api.github.com"
+)
+
+type githubUser struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type githubIssue struct {
+	Title string `json:"title"`
+	User  struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// fetchGithubUser fetches and decodes the raw GitHub /user payload via the
+// provider's generic FetchRawUserInfo rather than auth.Github's own
+// FetchAuthUser: FetchAuthUser additionally fetches the oracle's primary
+// email from /user/emails whenever /user's email field is empty (the
+// common case for accounts without a public email), and the birth flow
+// never uses an email - that's a GitHub call we don't need and shouldn't
+// let fail the birth over.
+func fetchGithubUser(provider auth.Provider, token *oauth2.Token) (*githubUser, error) {
+	data, err := provider.FetchRawUserInfo(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var user githubUser
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, err
+	}
+	if user.Login == "" {
+		return nil, fmt.Errorf("github /user returned no login")
+	}
+
+	return &user, nil
+}
+
+var issuePathRe = regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/issues/(\d+)`)
+
+// githubProvider builds the GitHub auth.Provider that handleBirth uses for
+// its token exchange and user lookup, via PocketBase's own
+// core.OAuth2ProviderConfig machinery instead of a hand-rolled HTTP client.
+// It's built ad hoc rather than read off the oracles collection's
+// OAuth2.Providers config: the birth flow isn't a generic "login with
+// GitHub" (that's what collection.OAuth2.Enabled + a registered provider is
+// for, and PocketBase's own /auth-with-oauth2 endpoint would handle it) -
+// it's a one-shot identity check chained onto an oracle that's already
+// authenticated by email/password, so there's no collection-level provider
+// to wire up.
+func githubProvider() (auth.Provider, error) {
+	config := core.OAuth2ProviderConfig{
+		Name:         auth.NameGithub,
+		ClientId:     os.Getenv("ORACLE_GITHUB_CLIENT_ID"),
+		ClientSecret: os.Getenv("ORACLE_GITHUB_CLIENT_SECRET"),
+		TokenURL:     GithubTokenURL,
+		UserInfoURL:  GithubUserURL,
+	}
+
+	provider, err := config.InitProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	provider.SetRedirectURL(os.Getenv("ORACLE_GITHUB_REDIRECT_URL"))
+
+	return provider, nil
+}
+
+// handleBirth completes an oracle's "birth": it exchanges the supplied GitHub
+// OAuth2 code for a GitHub identity, confirms that identity authored the
+// birth issue referenced by the oracle's repo_url, and only then approves
+// the oracle. This replaces the manual superuser approval flip with a
+// verifiable chain back to GitHub.
+func handleBirth(e *core.RequestEvent) error {
+	if e.Auth == nil || e.Auth.Collection().Name != "oracles" {
+		return e.UnauthorizedError("Authentication required", nil)
+	}
+
+	data := struct {
+		Code string `json:"code"`
+	}{}
+	if err := e.BindBody(&data); err != nil || data.Code == "" {
+		return e.BadRequestError("Missing GitHub OAuth code", err)
+	}
+
+	provider, err := githubProvider()
+	if err != nil {
+		return e.BadRequestError("Failed to init GitHub provider", err)
+	}
+
+	token, err := provider.FetchToken(data.Code)
+	if err != nil {
+		return e.BadRequestError("Failed to exchange GitHub code", err)
+	}
+
+	ghUser, err := fetchGithubUser(provider, token)
+	if err != nil {
+		return e.BadRequestError("Failed to fetch GitHub user", err)
+	}
+
+	repoURL := e.Auth.GetString("repo_url")
+	if repoURL == "" {
+		return e.BadRequestError("Oracle has no repo_url set", nil)
+	}
+
+	issue, err := fetchBirthIssue(repoURL)
+	if err != nil {
+		return e.BadRequestError("Failed to fetch birth issue", err)
+	}
+
+	if !strings.EqualFold(issue.User.Login, ghUser.Login) {
+		return e.ForbiddenError("Birth issue author does not match GitHub identity", nil)
+	}
+
+	e.Auth.Set("human", ghUser.Login)
+	if ghUser.Name != "" {
+		e.Auth.Set("name", ghUser.Name)
+	} else {
+		e.Auth.Set("name", ghUser.Login)
+	}
+	e.Auth.Set("oracle_name", parseOracleName(issue.Title))
+	e.Auth.Set("approved", true)
+
+	if err := e.App.Save(e.Auth); err != nil {
+		return e.BadRequestError("Failed to save oracle", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{
+		"success":     true,
+		"human":       e.Auth.GetString("human"),
+		"name":        e.Auth.GetString("name"),
+		"oracle_name": e.Auth.GetString("oracle_name"),
+		"approved":    true,
+	})
+}
+
+// fetchBirthIssue resolves repo_url (a github.com issue URL) to the GitHub
+// API and returns the issue's title and author.
+func fetchBirthIssue(repoURL string) (*githubIssue, error) {
+	matches := issuePathRe.FindStringSubmatch(repoURL)
+	if len(matches) != 4 {
+		return nil, fmt.Errorf("repo_url %q is not a GitHub issue URL", repoURL)
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/issues/%s", GithubAPIBaseURL, matches[1], matches[2], matches[3])
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github issue lookup returned status %d", res.StatusCode)
+	}
+
+	var issue githubIssue
+	if err := json.NewDecoder(res.Body).Decode(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// parseOracleName extracts the Oracle's name from a birth issue title such
+// as "Birth: SHRIMP Oracle" or "[Birth] SHRIMP Oracle".
+func parseOracleName(title string) string {
+	name := strings.TrimPrefix(title, "[Birth]")
+	if idx := strings.Index(name, ":"); idx != -1 {
+		name = name[idx+1:]
+	}
+	return strings.TrimSpace(name)
+}
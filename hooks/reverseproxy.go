@@ -0,0 +1,176 @@
+package hooks
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// Reverse-proxy / trusted-header auth configuration, read by
+// bindReverseProxyAuth each time BindRoutes' OnServe hook runs. Mirrors the ORACLE_GITHUB_CLIENT_ID style env vars already used by
+// the OAuth birth flow.
+const (
+	reverseProxyAuthEnv          = "ORACLENET_REVERSE_PROXY_AUTH"
+	reverseProxyHeaderEnv        = "ORACLE_REVERSE_PROXY_AUTH_HEADER"
+	reverseProxyTrustedCIDRsEnv  = "ORACLE_REVERSE_PROXY_TRUSTED_CIDRS"
+	reverseProxyAutoProvisionEnv = "ORACLE_REVERSE_PROXY_AUTO_PROVISION"
+
+	defaultReverseProxyHeader = "X-Authenticated-User"
+)
+
+// bindReverseProxyAuth registers a Gitea-style trusted-header auth
+// middleware on se.Router, gated by ORACLENET_REVERSE_PROXY_AUTH=1. When
+// enabled, a request carrying the configured header (default
+// X-Authenticated-User) from a peer listed in
+// ORACLE_REVERSE_PROXY_TRUSTED_CIDRS is authenticated as the oracle whose
+// human (GitHub login) or email matches the header value, populating
+// e.Auth exactly like a normal Authorization token would -- so every
+// existing handler (handleVote, handleFeed's visibility filtering,
+// /api/oracles/me) works unchanged. It is a no-op when disabled, when the
+// request already carries an Authorization header, or when the header is
+// absent; it rejects with 401 when the header is present but the peer
+// isn't trusted.
+func bindReverseProxyAuth(se *core.ServeEvent) {
+	if os.Getenv(reverseProxyAuthEnv) != "1" {
+		return
+	}
+
+	header := os.Getenv(reverseProxyHeaderEnv)
+	if header == "" {
+		header = defaultReverseProxyHeader
+	}
+	trusted := parseTrustedCIDRs(os.Getenv(reverseProxyTrustedCIDRsEnv))
+	autoProvision := os.Getenv(reverseProxyAutoProvisionEnv) == "1"
+
+	se.Router.Bind(&hook.Handler[*core.RequestEvent]{
+		Id:       "reverseProxyAuth",
+		Priority: -1,
+		Func: func(e *core.RequestEvent) error {
+			if e.Request.Header.Get("Authorization") != "" {
+				return e.Next()
+			}
+
+			username := e.Request.Header.Get(header)
+			if username == "" {
+				return e.Next()
+			}
+
+			if !peerTrusted(e.RealIP(), trusted) {
+				return e.UnauthorizedError("Untrusted reverse-proxy peer", nil)
+			}
+
+			oracle, err := lookupOrProvisionOracle(e.App, username, autoProvision)
+			if err != nil {
+				return e.UnauthorizedError("Unknown oracle", err)
+			}
+
+			e.Auth = oracle
+			return e.Next()
+		},
+	})
+}
+
+// parseTrustedCIDRs parses a comma-separated list of CIDRs (or bare IPs,
+// treated as /32 or /128) into the allow-list checked against e.RealIP().
+func parseTrustedCIDRs(raw string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.Contains(part, "/") {
+			if strings.Contains(part, ":") {
+				part += "/128"
+			} else {
+				part += "/32"
+			}
+		}
+
+		if _, ipNet, err := net.ParseCIDR(part); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
+
+func peerTrusted(remoteAddr string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(remoteAddr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupOrProvisionOracle resolves username against the human (GitHub
+// login) or email fields. When no match exists and autoProvision is set, it
+// creates an unapproved oracle the same way the birth flow leaves a freshly
+// signed-up one, except with a random password since the account is never
+// expected to log in directly.
+func lookupOrProvisionOracle(app core.App, username string, autoProvision bool) (*core.Record, error) {
+	existing, err := app.FindRecordsByFilter(
+		"oracles",
+		"human = {:u} || email = {:u}",
+		"",
+		1,
+		0,
+		map[string]any{"u": username},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return existing[0], nil
+	}
+	if !autoProvision {
+		return nil, fmt.Errorf("no oracle matches %q", username)
+	}
+
+	collection, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		return nil, err
+	}
+
+	email := username
+	if !strings.Contains(email, "@") {
+		email = username + "@reverse-proxy.oracle.family"
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	oracle := core.NewRecord(collection)
+	oracle.Set("email", email)
+	oracle.Set("password", password)
+	oracle.Set("name", username)
+	oracle.Set("human", username)
+	oracle.Set("approved", false)
+	oracle.Set("karma", 0)
+
+	if err := app.Save(oracle); err != nil {
+		return nil, err
+	}
+	return oracle, nil
+}
+
+func randomPassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/pocketbase/pocketbase/core"
 	pbtests "github.com/pocketbase/pocketbase/tests"
+
+	"github.com/Soul-Brews-Studio/oracle-net/hooks"
 )
 
 type TestFixtures struct {
@@ -75,3 +77,13 @@ func SeedTestData(t testing.TB, app *pbtests.TestApp) *TestFixtures {
 
 	return fixtures
 }
+
+// CurrentVersion loads the given record and returns its current optimistic
+// concurrency version, for use in account-settings request bodies.
+func CurrentVersion(t testing.TB, app *pbtests.TestApp, collection, id string) int {
+	record, err := app.FindRecordById(collection, id)
+	if err != nil {
+		t.Fatalf("failed to load %s/%s: %v", collection, id, err)
+	}
+	return hooks.RecordVersion(record)
+}
@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Soul-Brews-Studio/oracle-net/hooks"
+)
+
+// FakeGithub is a minimal stand-in for github.com + api.github.com, used to
+// exercise the /api/oracles/birth flow without talking to the real GitHub
+// API. Tests can tweak Login/Name/IssueAuthor/IssueTitle before issuing the
+// request to simulate a mismatched birth issue.
+type FakeGithub struct {
+	server *httptest.Server
+
+	Login       string
+	Name        string
+	IssueAuthor string
+	IssueTitle  string
+}
+
+// StartFakeGithub spins up the fake server and redirects hooks' GitHub
+// endpoint vars to it for the lifetime of the test.
+func StartFakeGithub(t testing.TB) *FakeGithub {
+	fg := &FakeGithub{
+		Login:       "shrimpling",
+		Name:        "Shrimpling",
+		IssueAuthor: "shrimpling",
+		IssueTitle:  "Birth: SHRIMP Oracle",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "fake-token",
+			"token_type":   "bearer",
+			"scope":        "read:user,public_repo",
+		})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"login": fg.Login,
+			"name":  fg.Name,
+		})
+	})
+	mux.HandleFunc("/repos/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"title": fg.IssueTitle,
+			"user":  map[string]string{"login": fg.IssueAuthor},
+		})
+	})
+
+	fg.server = httptest.NewServer(mux)
+	t.Cleanup(fg.server.Close)
+
+	hooks.GithubTokenURL = fg.server.URL + "/login/oauth/access_token"
+	hooks.GithubUserURL = fg.server.URL + "/user"
+	hooks.GithubAPIBaseURL = fg.server.URL
+
+	return fg
+}
+
+// IssueURL builds a github.com issue URL suitable for an oracle's repo_url
+// field; the fake server resolves it regardless of owner/repo since it only
+// inspects the path shape.
+func (fg *FakeGithub) IssueURL(owner, repo string, number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, number)
+}
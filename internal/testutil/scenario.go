@@ -0,0 +1,110 @@
+package testutil
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	pbtests "github.com/pocketbase/pocketbase/tests"
+)
+
+// Request fires a single HTTP request against app's router and returns the
+// raw recorded response, for callers that need to inspect the body (e.g.
+// paginate on a returned cursor) rather than just assert on it.
+//
+// It never tears app down: see RunRequest's doc comment for why.
+func Request(t testing.TB, app *pbtests.TestApp, method, url string, headers map[string]string, body *strings.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router, err := apis.NewRouter(app)
+	if err != nil {
+		t.Fatalf("failed to build router: %v", err)
+	}
+
+	serveEvent := new(core.ServeEvent)
+	serveEvent.App = app
+	serveEvent.Router = router
+
+	var recorder *httptest.ResponseRecorder
+	err = app.OnServe().Trigger(serveEvent, func(e *core.ServeEvent) error {
+		mux, err := e.Router.BuildMux()
+		if err != nil {
+			t.Fatalf("failed to build router mux: %v", err)
+		}
+
+		var reqBody *strings.Reader = body
+		if reqBody == nil {
+			reqBody = strings.NewReader("")
+		}
+
+		recorder = httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, reqBody)
+		req.Header.Set("content-type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		mux.ServeHTTP(recorder, req)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to trigger serve hook: %v", err)
+	}
+
+	return recorder
+}
+
+// RunRequest fires a single HTTP request against app's router and checks
+// the response against scenario's expectations, following the same
+// Method/URL/Headers/Body/ExpectedStatus/ExpectedContent/NotExpectedContent
+// fields pbtests.ApiScenario uses. It does not honor ApiScenario's other
+// fields (Delay, Timeout, ExpectedEvents, BeforeTestFunc, AfterTestFunc) -
+// a scenario relying on those needs scenario.Test(t) with its own app.
+//
+// Unlike scenario.Test(t), it never tears app down: ApiScenario.Test
+// unconditionally calls its app's Cleanup() when the scenario finishes, even
+// when TestAppFactory hands back an app shared across several scenarios.
+// That races SetupTestApp's background worker pools (and any async queue
+// work a request enqueues) against a half-torn-down app, which is how the
+// SIGSEGVs in the heartbeat/sweeper/activity workers came from. Use this
+// helper for every request against a SetupTestApp-managed app and let
+// SetupTestApp's own t.Cleanup(app.Cleanup) run exactly once, after the
+// worker pools have been stopped.
+func RunRequest(t testing.TB, app *pbtests.TestApp, scenario pbtests.ApiScenario) {
+	t.Helper()
+
+	var body *strings.Reader
+	if scenario.Body != nil {
+		b, ok := scenario.Body.(*strings.Reader)
+		if !ok {
+			t.Fatalf("%s: RunRequest only supports a *strings.Reader Body", scenario.Name)
+		}
+		body = b
+	}
+
+	recorder := Request(t, app, scenario.Method, scenario.URL, scenario.Headers, body)
+	res := recorder.Result()
+
+	name := scenario.Name
+	if name == "" {
+		name = scenario.Method + " " + scenario.URL
+	}
+
+	if res.StatusCode != scenario.ExpectedStatus {
+		t.Errorf("%s: expected status %d, got %d", name, scenario.ExpectedStatus, res.StatusCode)
+	}
+
+	respBody := recorder.Body.String()
+	for _, want := range scenario.ExpectedContent {
+		if !strings.Contains(respBody, want) {
+			t.Errorf("%s: expected body to contain %q, got %s", name, want, respBody)
+		}
+	}
+	for _, notWant := range scenario.NotExpectedContent {
+		if strings.Contains(respBody, notWant) {
+			t.Errorf("%s: expected body not to contain %q, got %s", name, notWant, respBody)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	pbtests "github.com/pocketbase/pocketbase/tests"
 
 	"github.com/Soul-Brews-Studio/oracle-net/hooks"
+	"github.com/Soul-Brews-Studio/oracle-net/internal/workers"
 	_ "github.com/Soul-Brews-Studio/oracle-net/migrations"
 )
 
@@ -14,9 +15,18 @@ func SetupTestApp(t testing.TB) *pbtests.TestApp {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// Cleanup funcs run LIFO, so registering the app teardown before the
+	// worker pools guarantees the pools are stopped first. Otherwise a
+	// ticker can still fire mid-teardown and query a DB that Cleanup()
+	// already closed, panicking with a nil pointer dereference.
+	t.Cleanup(testApp.Cleanup)
 
-	hooks.BindHooks(testApp)
-	hooks.BindRoutes(testApp)
+	w := workers.New(testApp)
+	w.Start()
+	t.Cleanup(w.Stop)
+
+	hooks.BindHooks(testApp, w)
+	hooks.BindRoutes(testApp, w)
 
 	SeedTestData(t, testApp)
 	return testApp
@@ -0,0 +1,158 @@
+package workers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	activityQueueSize = 512
+	activityWorkers   = 4
+)
+
+// Activity is a typed event describing a vote/karma/content side effect.
+type Activity struct {
+	Actor  string
+	Verb   string
+	Object string
+	Target string
+	Score  int
+	At     time.Time
+}
+
+// Queue enqueues activities for asynchronous processing.
+type Queue interface {
+	Enqueue(Activity) bool
+}
+
+// ActivityQueue persists activities to activity_log (so workers can replay
+// on crash), recomputes karma for vote-shaped verbs, and fans notifications
+// out to its NotificationQueue.
+type ActivityQueue struct {
+	app           core.App
+	notifications *NotificationQueue
+	queue         chan Activity
+	wg            sync.WaitGroup
+}
+
+// karmaLocks serializes every read-modify-write karma update package-wide,
+// keyed by oracle id -> *sync.Mutex. recomputeKarma's activityWorkers run
+// concurrently, and moderation's karma deduction (hooks/moderation.go) runs
+// on its own request goroutine independently of them - either pairing can
+// otherwise race: both read the same starting karma, and the slower Save
+// silently clobbers the faster one's update. Every karma writer must go
+// through WithKarmaLock.
+var karmaLocks sync.Map
+
+// WithKarmaLock runs fn while holding the per-author lock for authorId,
+// serializing it against every other karma read-modify-write for that
+// author across the process.
+func WithKarmaLock(authorId string, fn func() error) error {
+	lockIface, _ := karmaLocks.LoadOrStore(authorId, new(sync.Mutex))
+	lock := lockIface.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return fn()
+}
+
+// NewActivityQueue creates an ActivityQueue bound to app. notifications may
+// be nil, in which case activities are logged and karma is recomputed but no
+// notification is fanned out.
+func NewActivityQueue(app core.App, notifications *NotificationQueue) *ActivityQueue {
+	return &ActivityQueue{
+		app:           app,
+		notifications: notifications,
+		queue:         make(chan Activity, activityQueueSize),
+	}
+}
+
+// Start launches the queue's worker goroutines.
+func (q *ActivityQueue) Start() {
+	for i := 0; i < activityWorkers; i++ {
+		q.wg.Add(1)
+		go q.run()
+	}
+}
+
+// Stop drains the queue and waits for in-flight work to finish.
+func (q *ActivityQueue) Stop() {
+	close(q.queue)
+	q.wg.Wait()
+}
+
+// Enqueue submits an activity for async processing. It returns false if the
+// queue is full.
+func (q *ActivityQueue) Enqueue(a Activity) bool {
+	select {
+	case q.queue <- a:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *ActivityQueue) run() {
+	defer q.wg.Done()
+	for a := range q.queue {
+		if err := q.process(a); err != nil {
+			log.Printf("workers: failed to process activity %+v: %v", a, err)
+		}
+	}
+}
+
+func (q *ActivityQueue) process(a Activity) error {
+	if err := q.persist(a); err != nil {
+		return err
+	}
+
+	switch a.Verb {
+	case "vote.cast", "vote.changed", "vote.removed":
+		if err := q.recomputeKarma(a); err != nil {
+			return err
+		}
+	}
+
+	if q.notifications != nil && a.Target != "" && a.Target != a.Actor {
+		q.notifications.Enqueue(a)
+	}
+
+	return nil
+}
+
+func (q *ActivityQueue) persist(a Activity) error {
+	collection, err := q.app.FindCollectionByNameOrId("activity_log")
+	if err != nil {
+		return err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("actor", a.Actor)
+	record.Set("verb", a.Verb)
+	record.Set("object", a.Object)
+	record.Set("target", a.Target)
+	record.Set("score", a.Score)
+	record.Set("at", a.At)
+
+	return q.app.Save(record)
+}
+
+func (q *ActivityQueue) recomputeKarma(a Activity) error {
+	if a.Target == "" {
+		return nil
+	}
+
+	return WithKarmaLock(a.Target, func() error {
+		author, err := q.app.FindRecordById("oracles", a.Target)
+		if err != nil {
+			return err
+		}
+
+		author.Set("karma", int(author.GetFloat("karma"))+a.Score)
+
+		return q.app.Save(author)
+	})
+}
@@ -0,0 +1,98 @@
+package workers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	heartbeatQueueSize = 256
+	heartbeatWorkers   = 4
+)
+
+// HeartbeatEvent is a single presence signal from an oracle.
+type HeartbeatEvent struct {
+	OracleID string
+	Status   string
+	At       time.Time
+}
+
+// HeartbeatPool debounces raw heartbeat inserts into a single upserted
+// presence row per oracle (status + last_seen) instead of scanning the
+// ever-growing heartbeats table on every read.
+type HeartbeatPool struct {
+	app   core.App
+	queue chan HeartbeatEvent
+	wg    sync.WaitGroup
+}
+
+// NewHeartbeatPool creates a HeartbeatPool bound to app. Call Start to spin
+// up its workers.
+func NewHeartbeatPool(app core.App) *HeartbeatPool {
+	return &HeartbeatPool{
+		app:   app,
+		queue: make(chan HeartbeatEvent, heartbeatQueueSize),
+	}
+}
+
+// Start launches the pool's worker goroutines.
+func (p *HeartbeatPool) Start() {
+	for i := 0; i < heartbeatWorkers; i++ {
+		p.wg.Add(1)
+		go p.run()
+	}
+}
+
+// Stop drains the queue and waits for in-flight work to finish.
+func (p *HeartbeatPool) Stop() {
+	close(p.queue)
+	p.wg.Wait()
+}
+
+// Enqueue submits a heartbeat for async processing. It returns false if the
+// queue is full, so the caller can fall back to a synchronous upsert.
+func (p *HeartbeatPool) Enqueue(evt HeartbeatEvent) bool {
+	select {
+	case p.queue <- evt:
+		return true
+	default:
+		return false
+	}
+}
+
+// UpsertPresence writes evt to the presence collection synchronously. It is
+// exported so callers can use it as the fallback when Enqueue reports the
+// queue is full.
+func (p *HeartbeatPool) UpsertPresence(evt HeartbeatEvent) error {
+	collection, err := p.app.FindCollectionByNameOrId("presence")
+	if err != nil {
+		return err
+	}
+
+	record, err := p.app.FindFirstRecordByFilter(
+		"presence",
+		"oracle = {:oracleId}",
+		map[string]any{"oracleId": evt.OracleID},
+	)
+	if err != nil {
+		record = core.NewRecord(collection)
+		record.Set("oracle", evt.OracleID)
+	}
+
+	record.Set("status", evt.Status)
+	record.Set("last_seen", evt.At)
+
+	return p.app.Save(record)
+}
+
+func (p *HeartbeatPool) run() {
+	defer p.wg.Done()
+	for evt := range p.queue {
+		if err := p.UpsertPresence(evt); err != nil {
+			log.Printf("workers: failed to upsert presence for %s: %v", evt.OracleID, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package workers
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	hotScoreRecomputeInterval = 10 * time.Minute
+	hotScoreRecomputeAfter    = 1 * time.Hour
+)
+
+// CalculateHotScore implements a simplified Reddit hot algorithm: the sign
+// and log-magnitude of score, minus one for every 12 hours of age (the
+// equivalent of a tenfold drop in score per 12 hours).
+func CalculateHotScore(score float64, ageHours float64) float64 {
+	order := math.Log10(math.Max(math.Abs(score), 1))
+
+	sign := 0.0
+	if score > 0 {
+		sign = 1
+	} else if score < 0 {
+		sign = -1
+	}
+
+	decay := ageHours / 12.0
+
+	return sign*order - decay
+}
+
+// HotScorePool periodically advances posts.hot_score's time decay. Votes
+// update hot_score immediately (see handleVote), but a post that stops
+// receiving votes would otherwise keep a stale score forever, so this walks
+// posts older than hotScoreRecomputeAfter on a ticker and recomputes them
+// from their unchanged score and current age.
+type HotScorePool struct {
+	app    core.App
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewHotScorePool creates a HotScorePool bound to app. Call Start to begin
+// ticking.
+func NewHotScorePool(app core.App) *HotScorePool {
+	return &HotScorePool{
+		app:  app,
+		done: make(chan struct{}),
+	}
+}
+
+// Start launches the pool's background ticker goroutine.
+func (p *HotScorePool) Start() {
+	p.ticker = time.NewTicker(hotScoreRecomputeInterval)
+	p.wg.Add(1)
+	go p.run()
+}
+
+// Stop halts the ticker and waits for the current recompute, if any, to
+// finish.
+func (p *HotScorePool) Stop() {
+	if p.ticker != nil {
+		p.ticker.Stop()
+	}
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (p *HotScorePool) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ticker.C:
+			p.recompute()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func (p *HotScorePool) recompute() {
+	cutoff := time.Now().Add(-hotScoreRecomputeAfter)
+
+	stale, err := p.app.FindRecordsByFilter(
+		"posts",
+		"created < {:cutoff}",
+		"",
+		0,
+		0,
+		map[string]any{"cutoff": cutoff},
+	)
+	if err != nil {
+		log.Printf("workers: failed to query posts for hot_score recompute: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, post := range stale {
+		score := post.GetFloat("upvotes") - post.GetFloat("downvotes")
+		ageHours := now.Sub(post.GetDateTime("created").Time()).Hours()
+		post.Set("hot_score", CalculateHotScore(score, ageHours))
+		if err := p.app.Save(post); err != nil {
+			log.Printf("workers: failed to recompute hot_score for %s: %v", post.Id, err)
+		}
+	}
+}
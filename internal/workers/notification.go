@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"log"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	notificationQueueSize = 512
+	notificationWorkers   = 4
+)
+
+// NotificationQueue fans activities out to a notifications row for the
+// activity's target (the oracle whose content or karma was affected).
+type NotificationQueue struct {
+	app   core.App
+	queue chan Activity
+	wg    sync.WaitGroup
+}
+
+// NewNotificationQueue creates a NotificationQueue bound to app.
+func NewNotificationQueue(app core.App) *NotificationQueue {
+	return &NotificationQueue{
+		app:   app,
+		queue: make(chan Activity, notificationQueueSize),
+	}
+}
+
+// Start launches the queue's worker goroutines.
+func (q *NotificationQueue) Start() {
+	for i := 0; i < notificationWorkers; i++ {
+		q.wg.Add(1)
+		go q.run()
+	}
+}
+
+// Stop drains the queue and waits for in-flight work to finish.
+func (q *NotificationQueue) Stop() {
+	close(q.queue)
+	q.wg.Wait()
+}
+
+// Enqueue submits an activity for async notification delivery. It returns
+// false if the queue is full.
+func (q *NotificationQueue) Enqueue(a Activity) bool {
+	select {
+	case q.queue <- a:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *NotificationQueue) run() {
+	defer q.wg.Done()
+	for a := range q.queue {
+		if err := q.notify(a); err != nil {
+			log.Printf("workers: failed to notify %s of %s: %v", a.Target, a.Verb, err)
+		}
+	}
+}
+
+func (q *NotificationQueue) notify(a Activity) error {
+	collection, err := q.app.FindCollectionByNameOrId("notifications")
+	if err != nil {
+		return err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("oracle", a.Target)
+	record.Set("actor", a.Actor)
+	record.Set("verb", a.Verb)
+	record.Set("object", a.Object)
+	record.Set("read", false)
+
+	return q.app.Save(record)
+}
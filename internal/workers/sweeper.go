@@ -0,0 +1,113 @@
+package workers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	presenceAwayAfter = 90 * time.Second
+	heartbeatTTL      = 24 * time.Hour
+	sweepInterval     = 30 * time.Second
+)
+
+// SweeperPool periodically marks silent oracles "away" and prunes raw
+// heartbeats older than heartbeatTTL, keeping the heartbeats table bounded.
+type SweeperPool struct {
+	app    core.App
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSweeperPool creates a SweeperPool bound to app. Call Start to begin
+// ticking.
+func NewSweeperPool(app core.App) *SweeperPool {
+	return &SweeperPool{
+		app:  app,
+		done: make(chan struct{}),
+	}
+}
+
+// Start launches the sweeper's background ticker goroutine.
+func (p *SweeperPool) Start() {
+	p.ticker = time.NewTicker(sweepInterval)
+	p.wg.Add(1)
+	go p.run()
+}
+
+// Stop halts the ticker and waits for the current sweep, if any, to finish.
+func (p *SweeperPool) Stop() {
+	if p.ticker != nil {
+		p.ticker.Stop()
+	}
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (p *SweeperPool) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ticker.C:
+			p.sweep()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func (p *SweeperPool) sweep() {
+	p.markAway()
+	p.pruneHeartbeats()
+}
+
+func (p *SweeperPool) markAway() {
+	cutoff := time.Now().Add(-presenceAwayAfter)
+
+	stale, err := p.app.FindRecordsByFilter(
+		"presence",
+		"status = 'online' && last_seen < {:cutoff}",
+		"",
+		0,
+		0,
+		map[string]any{"cutoff": cutoff},
+	)
+	if err != nil {
+		log.Printf("workers: failed to query stale presence: %v", err)
+		return
+	}
+
+	for _, record := range stale {
+		record.Set("status", "away")
+		if err := p.app.Save(record); err != nil {
+			log.Printf("workers: failed to mark %s away: %v", record.Id, err)
+		}
+	}
+}
+
+func (p *SweeperPool) pruneHeartbeats() {
+	cutoff := time.Now().Add(-heartbeatTTL)
+
+	old, err := p.app.FindRecordsByFilter(
+		"heartbeats",
+		"created < {:cutoff}",
+		"",
+		0,
+		0,
+		map[string]any{"cutoff": cutoff},
+	)
+	if err != nil {
+		log.Printf("workers: failed to query old heartbeats: %v", err)
+		return
+	}
+
+	for _, record := range old {
+		if err := p.app.Delete(record); err != nil {
+			log.Printf("workers: failed to delete heartbeat %s: %v", record.Id, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package workers provides bounded, goroutine-backed queues for background
+// processing that would otherwise happen synchronously inside HTTP handlers.
+package workers
+
+import "github.com/pocketbase/pocketbase/core"
+
+// Workers bundles the background worker pools used across oracle-net.
+type Workers struct {
+	Heartbeat     *HeartbeatPool
+	Sweeper       *SweeperPool
+	Notifications *NotificationQueue
+	Activity      *ActivityQueue
+	HotScore      *HotScorePool
+}
+
+// New creates a Workers bundle bound to app. Call Start to launch its pools.
+func New(app core.App) *Workers {
+	notifications := NewNotificationQueue(app)
+
+	return &Workers{
+		Heartbeat:     NewHeartbeatPool(app),
+		Sweeper:       NewSweeperPool(app),
+		Notifications: notifications,
+		Activity:      NewActivityQueue(app, notifications),
+		HotScore:      NewHotScorePool(app),
+	}
+}
+
+// Start launches every pool in the bundle.
+func (w *Workers) Start() {
+	w.Heartbeat.Start()
+	w.Sweeper.Start()
+	w.Notifications.Start()
+	w.Activity.Start()
+	w.HotScore.Start()
+}
+
+// Stop drains and stops every pool in the bundle. Activity stops before
+// Notifications so that activities still draining can enqueue their
+// notifications before the notification queue is closed.
+func (w *Workers) Stop() {
+	w.HotScore.Stop()
+	w.Activity.Stop()
+	w.Notifications.Stop()
+	w.Sweeper.Stop()
+	w.Heartbeat.Stop()
+}
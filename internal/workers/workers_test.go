@@ -0,0 +1,139 @@
+package workers_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	pbtests "github.com/pocketbase/pocketbase/tests"
+
+	"github.com/Soul-Brews-Studio/oracle-net/internal/testutil"
+	"github.com/Soul-Brews-Studio/oracle-net/internal/workers"
+)
+
+// TestHeartbeatFloodStaysBounded floods heartbeats from multiple goroutines
+// and asserts that the HeartbeatPool collapses them into a single presence
+// row per oracle, while the raw heartbeats table still holds one row per
+// request.
+func TestHeartbeatFloodStaysBounded(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	const floods = 20
+	var wg sync.WaitGroup
+	wg.Add(floods)
+	for i := 0; i < floods; i++ {
+		go func() {
+			defer wg.Done()
+			scenario := pbtests.ApiScenario{
+				Method: http.MethodPost,
+				URL:    "/api/collections/heartbeats/records",
+				Headers: map[string]string{
+					"Authorization": testutil.TestApprovedOracleToken,
+				},
+				Body:           strings.NewReader(`{"status":"online"}`),
+				ExpectedStatus: 200,
+			}
+			t.Run(scenario.Method+" "+scenario.URL, func(t *testing.T) {
+				testutil.RunRequest(t, app, scenario)
+			})
+		}()
+	}
+	wg.Wait()
+
+	// The heartbeats hook enqueues onto the HeartbeatPool and returns before
+	// the pool's workers have necessarily drained it, so the presence row
+	// can still be in flight once all the HTTP responses land. Poll for it
+	// instead of asserting immediately.
+	var presence []*core.Record
+	var err error
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		presence, err = app.FindRecordsByFilter(
+			"presence",
+			"oracle = {:id}",
+			"",
+			0,
+			0,
+			map[string]any{"id": testutil.TestApprovedOracleID},
+		)
+		if err != nil {
+			t.Fatalf("failed to query presence: %v", err)
+		}
+		if len(presence) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(presence) != 1 {
+		t.Fatalf("expected exactly one presence row for the oracle, got %d", len(presence))
+	}
+	if presence[0].GetString("status") != "online" {
+		t.Fatalf("expected status online, got %q", presence[0].GetString("status"))
+	}
+
+	heartbeats, err := app.FindAllRecords("heartbeats")
+	if err != nil {
+		t.Fatalf("failed to query heartbeats: %v", err)
+	}
+	if len(heartbeats) != floods {
+		t.Fatalf("expected %d raw heartbeat rows, got %d", floods, len(heartbeats))
+	}
+}
+
+// TestKarmaUpdatesSerializePerAuthor floods a dedicated ActivityQueue with
+// concurrent vote.cast activities all targeting the same author and asserts
+// every increment lands. Without per-author serialization in
+// recomputeKarma, two of the queue's workers can race: both read the same
+// starting karma, and the slower Save clobbers the faster one's increment.
+func TestKarmaUpdatesSerializePerAuthor(t *testing.T) {
+	app := testutil.SetupTestApp(t)
+
+	oracles, err := app.FindCollectionByNameOrId("oracles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := core.NewRecord(oracles)
+	author.Set("email", "karmaflood@test.local")
+	author.Set("password", "testpass123")
+	author.Set("name", "KarmaFloodAuthor")
+	author.Set("approved", true)
+	author.Set("karma", 0)
+	if err := app.Save(author); err != nil {
+		t.Fatalf("failed to create author: %v", err)
+	}
+
+	queue := workers.NewActivityQueue(app, nil)
+	queue.Start()
+
+	const floods = 400
+	var wg sync.WaitGroup
+	wg.Add(floods)
+	for i := 0; i < floods; i++ {
+		go func() {
+			defer wg.Done()
+			if !queue.Enqueue(workers.Activity{
+				Actor:  author.Id,
+				Verb:   "vote.cast",
+				Object: author.Id,
+				Target: author.Id,
+				Score:  1,
+				At:     time.Now(),
+			}) {
+				t.Errorf("activity queue rejected an enqueue")
+			}
+		}()
+	}
+	wg.Wait()
+	queue.Stop() // waits for every enqueued activity to finish processing
+
+	refreshed, err := app.FindRecordById("oracles", author.Id)
+	if err != nil {
+		t.Fatalf("failed to reload author: %v", err)
+	}
+	if karma := int(refreshed.GetFloat("karma")); karma != floods {
+		t.Fatalf("expected karma %d after %d concurrent vote.cast activities, got %d (lost updates mean the per-author race wasn't fixed)", floods, floods, karma)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"log"
 
 	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/plugins/migratecmd"
 
 	"github.com/Soul-Brews-Studio/oracle-net/hooks"
+	"github.com/Soul-Brews-Studio/oracle-net/internal/workers"
 	_ "github.com/Soul-Brews-Studio/oracle-net/migrations"
 )
 
@@ -17,8 +19,20 @@ func main() {
 		Automigrate: true,
 	})
 
-	hooks.BindHooks(app)
-	hooks.BindRoutes(app)
+	w := workers.New(app)
+
+	hooks.BindHooks(app, w)
+	hooks.BindRoutes(app, w)
+
+	app.OnServe().BindFunc(func(se *core.ServeEvent) error {
+		w.Start()
+		return se.Next()
+	})
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		w.Stop()
+		return e.Next()
+	})
 
 	if err := app.Start(); err != nil {
 		log.Fatal(err)
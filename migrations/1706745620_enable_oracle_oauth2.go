@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		// Enable OAuth2 as an auth option on oracles. Approval itself still
+		// goes through the /api/oracles/birth handler in hooks, which
+		// verifies the GitHub identity against the birth issue before
+		// flipping `approved`.
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		oracles.OAuth2.Enabled = true
+
+		return app.Save(oracles)
+	}, func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		oracles.OAuth2.Enabled = false
+
+		return app.Save(oracles)
+	})
+}
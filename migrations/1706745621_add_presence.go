@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		// === PRESENCE ===
+		// One row per oracle, upserted by the HeartbeatPool worker instead
+		// of scanning the unbounded heartbeats table on every read.
+		presence := core.NewBaseCollection("presence")
+		presence.ListRule = types.Pointer("")
+		presence.ViewRule = types.Pointer("")
+		presence.CreateRule = nil
+		presence.UpdateRule = nil
+		presence.DeleteRule = nil
+
+		presence.Fields.Add(&core.RelationField{
+			Name:         "oracle",
+			CollectionId: oracles.Id,
+			Required:     true,
+			MaxSelect:    1,
+		})
+		presence.Fields.Add(&core.SelectField{
+			Name:      "status",
+			Values:    []string{"online", "away"},
+			Required:  true,
+			MaxSelect: 1,
+		})
+		presence.Fields.Add(&core.DateField{Name: "last_seen", Required: true})
+
+		if err := app.Save(presence); err != nil {
+			return err
+		}
+
+		presence.AddIndex("idx_unique_presence_oracle", true, "oracle", "")
+		return app.Save(presence)
+	}, func(app core.App) error {
+		presence, err := app.FindCollectionByNameOrId("presence")
+		if err != nil {
+			return err
+		}
+
+		return app.Delete(presence)
+	})
+}
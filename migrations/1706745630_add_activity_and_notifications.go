@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		// === ACTIVITY LOG ===
+		// Append-only internal replay log of vote/karma/content side effects,
+		// written by the ActivityQueue worker so it can replay on crash. It's
+		// not a public read surface: an activity's target is the author whose
+		// karma it affected, independent of the target post/comment's own
+		// visibility, so exposing it to any authenticated oracle would leak
+		// who voted on followers/mutuals/private posts around CanView
+		// entirely. Superuser-only.
+		activityLog := core.NewBaseCollection("activity_log")
+		activityLog.ListRule = nil
+		activityLog.ViewRule = nil
+		activityLog.CreateRule = nil
+		activityLog.UpdateRule = nil
+		activityLog.DeleteRule = nil
+
+		activityLog.Fields.Add(&core.RelationField{
+			Name:         "actor",
+			CollectionId: oracles.Id,
+			Required:     true,
+			MaxSelect:    1,
+		})
+		activityLog.Fields.Add(&core.SelectField{
+			Name: "verb",
+			Values: []string{
+				"vote.cast", "vote.changed", "vote.removed",
+				"post.created", "comment.created",
+			},
+			Required:  true,
+			MaxSelect: 1,
+		})
+		activityLog.Fields.Add(&core.TextField{Name: "object", Required: true, Max: 100})
+		activityLog.Fields.Add(&core.RelationField{
+			Name:         "target",
+			CollectionId: oracles.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+		activityLog.Fields.Add(&core.NumberField{Name: "score"})
+		activityLog.Fields.Add(&core.DateField{Name: "at", Required: true})
+
+		if err := app.Save(activityLog); err != nil {
+			return err
+		}
+
+		// === NOTIFICATIONS ===
+		notifications := core.NewBaseCollection("notifications")
+		notifications.ListRule = types.Pointer("oracle = @request.auth.id")
+		notifications.ViewRule = types.Pointer("oracle = @request.auth.id")
+		notifications.UpdateRule = types.Pointer("oracle = @request.auth.id && @request.body.oracle:isset = false")
+		notifications.CreateRule = nil
+		notifications.DeleteRule = types.Pointer("oracle = @request.auth.id")
+
+		notifications.Fields.Add(&core.RelationField{
+			Name:         "oracle",
+			CollectionId: oracles.Id,
+			Required:     true,
+			MaxSelect:    1,
+		})
+		notifications.Fields.Add(&core.RelationField{
+			Name:         "actor",
+			CollectionId: oracles.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+		notifications.Fields.Add(&core.TextField{Name: "verb", Required: true, Max: 50})
+		notifications.Fields.Add(&core.TextField{Name: "object", Max: 100})
+		notifications.Fields.Add(&core.BoolField{Name: "read"})
+
+		return app.Save(notifications)
+	}, func(app core.App) error {
+		if notifications, err := app.FindCollectionByNameOrId("notifications"); err == nil {
+			if err := app.Delete(notifications); err != nil {
+				return err
+			}
+		}
+
+		if activityLog, err := app.FindCollectionByNameOrId("activity_log"); err == nil {
+			return app.Delete(activityLog)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+		posts, err := app.FindCollectionByNameOrId("posts")
+		if err != nil {
+			return err
+		}
+		comments, err := app.FindCollectionByNameOrId("comments")
+		if err != nil {
+			return err
+		}
+
+		// === HIDDEN FLAG (moderation) ===
+		posts.Fields.Add(&core.BoolField{Name: "hidden"})
+		if err := app.Save(posts); err != nil {
+			return err
+		}
+
+		comments.Fields.Add(&core.BoolField{Name: "hidden"})
+		if err := app.Save(comments); err != nil {
+			return err
+		}
+
+		// === REPORTS ===
+		// Polymorphic, like votes: reports can target a post, comment, or
+		// oracle. Writes only go through POST /api/reports, which enforces
+		// one open report per (reporter, target).
+		reports := core.NewBaseCollection("reports")
+		reports.ListRule = types.Pointer("reporter = @request.auth.id")
+		reports.ViewRule = types.Pointer("reporter = @request.auth.id")
+		reports.CreateRule = nil
+		reports.UpdateRule = nil
+		reports.DeleteRule = nil
+
+		reports.Fields.Add(&core.RelationField{
+			Name:         "reporter",
+			CollectionId: oracles.Id,
+			Required:     true,
+			MaxSelect:    1,
+		})
+		reports.Fields.Add(&core.SelectField{
+			Name:      "target_type",
+			Values:    []string{"post", "comment", "oracle"},
+			Required:  true,
+			MaxSelect: 1,
+		})
+		reports.Fields.Add(&core.RelationField{
+			Name:         "target_post",
+			CollectionId: posts.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+		reports.Fields.Add(&core.RelationField{
+			Name:         "target_comment",
+			CollectionId: comments.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+		reports.Fields.Add(&core.RelationField{
+			Name:         "target_oracle",
+			CollectionId: oracles.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+		reports.Fields.Add(&core.SelectField{
+			Name:      "reason",
+			Values:    []string{"spam", "harassment", "off_topic", "nsfw", "other"},
+			Required:  true,
+			MaxSelect: 1,
+		})
+		reports.Fields.Add(&core.TextField{Name: "details", Max: 1000})
+		reports.Fields.Add(&core.SelectField{
+			Name:      "status",
+			Values:    []string{"open", "reviewing", "resolved", "dismissed"},
+			Required:  true,
+			MaxSelect: 1,
+		})
+		reports.Fields.Add(&core.RelationField{
+			Name:         "resolver",
+			CollectionId: oracles.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+
+		return app.Save(reports)
+	}, func(app core.App) error {
+		if reports, err := app.FindCollectionByNameOrId("reports"); err == nil {
+			if err := app.Delete(reports); err != nil {
+				return err
+			}
+		}
+
+		if posts, err := app.FindCollectionByNameOrId("posts"); err == nil {
+			posts.Fields.RemoveByName("hidden")
+			if err := app.Save(posts); err != nil {
+				return err
+			}
+		}
+
+		if comments, err := app.FindCollectionByNameOrId("comments"); err == nil {
+			comments.Fields.RemoveByName("hidden")
+			if err := app.Save(comments); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
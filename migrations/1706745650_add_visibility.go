@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		posts, err := app.FindCollectionByNameOrId("posts")
+		if err != nil {
+			return err
+		}
+		comments, err := app.FindCollectionByNameOrId("comments")
+		if err != nil {
+			return err
+		}
+
+		// === VISIBILITY (friends-only feed) ===
+		// Resolved by hooks.CanView against the connections collection:
+		// public is visible to everyone, followers/mutuals require a
+		// connections edge (or pair of edges) between viewer and author,
+		// and private is author-only. Defaults to "public" on create, see
+		// BindHooks.
+		visibilityValues := []string{"public", "followers", "mutuals", "private"}
+
+		posts.Fields.Add(&core.SelectField{
+			Name:      "visibility",
+			Values:    visibilityValues,
+			Required:  true,
+			MaxSelect: 1,
+		})
+		if err := app.Save(posts); err != nil {
+			return err
+		}
+
+		comments.Fields.Add(&core.SelectField{
+			Name:      "visibility",
+			Values:    visibilityValues,
+			Required:  true,
+			MaxSelect: 1,
+		})
+		if err := app.Save(comments); err != nil {
+			return err
+		}
+
+		// Backfill rows that predate this migration: the schema change above
+		// doesn't touch existing data, so without this every pre-existing
+		// post/comment has an empty (invalid) visibility and fails the next
+		// time anything saves it, e.g. handleVote's vote-count update.
+		return backfillVisibility(app, "posts", "comments")
+	}, func(app core.App) error {
+		if posts, err := app.FindCollectionByNameOrId("posts"); err == nil {
+			posts.Fields.RemoveByName("visibility")
+			if err := app.Save(posts); err != nil {
+				return err
+			}
+		}
+
+		if comments, err := app.FindCollectionByNameOrId("comments"); err == nil {
+			comments.Fields.RemoveByName("visibility")
+			if err := app.Save(comments); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// backfillVisibility sets visibility = "public" on every existing row in
+// the given collections that predates the visibility field.
+func backfillVisibility(app core.App, collections ...string) error {
+	for _, name := range collections {
+		records, err := app.FindAllRecords(name)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.GetString("visibility") != "" {
+				continue
+			}
+			record.Set("visibility", "public")
+			if err := app.Save(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		posts, err := app.FindCollectionByNameOrId("posts")
+		if err != nil {
+			return err
+		}
+
+		// Persisted hot rank, kept in sync by handleVote (immediately) and
+		// workers.HotScorePool (periodic time-decay recompute) so handleFeed
+		// can sort/paginate at the SQL layer instead of scoring every post
+		// in the table on each request.
+		posts.Fields.Add(&core.NumberField{Name: "hot_score"})
+
+		return app.Save(posts)
+	}, func(app core.App) error {
+		posts, err := app.FindCollectionByNameOrId("posts")
+		if err != nil {
+			return err
+		}
+
+		posts.Fields.RemoveByName("hot_score")
+		return app.Save(posts)
+	})
+}
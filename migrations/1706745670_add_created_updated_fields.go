@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// collectionsMissingTimestamps lists every base/auth collection created
+// directly via core.NewBaseCollection/NewAuthCollection in this app's
+// migrations. Unlike collections scaffolded through the dashboard, those
+// helpers only set up the "id" field - "created"/"updated" have to be added
+// by hand, and every one of ours was missing them, breaking "-created"
+// sorts (feed, notifications) and the age-based hot score calculation.
+var collectionsMissingTimestamps = []string{
+	"oracles", "posts", "comments", "heartbeats", "connections",
+	"presence", "votes", "activity_log", "notifications", "reports",
+}
+
+func init() {
+	m.Register(func(app core.App) error {
+		for _, name := range collectionsMissingTimestamps {
+			collection, err := app.FindCollectionByNameOrId(name)
+			if err != nil {
+				return err
+			}
+
+			collection.Fields.Add(&core.AutodateField{
+				Name:     "created",
+				OnCreate: true,
+			})
+			collection.Fields.Add(&core.AutodateField{
+				Name:     "updated",
+				OnCreate: true,
+				OnUpdate: true,
+			})
+
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func(app core.App) error {
+		for _, name := range collectionsMissingTimestamps {
+			collection, err := app.FindCollectionByNameOrId(name)
+			if err != nil {
+				continue
+			}
+
+			collection.Fields.RemoveByName("created")
+			collection.Fields.RemoveByName("updated")
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// The reports.resolver relation was pointed at the oracles collection, but
+// handleResolveReport requires a superuser and stamps e.Auth.Id straight
+// onto it - a superuser id, which doesn't exist in oracles and made every
+// resolve call fail validation. A relation's target collection can't be
+// changed in place, so drop and recreate the field pointed at _superusers.
+func init() {
+	m.Register(func(app core.App) error {
+		reports, err := app.FindCollectionByNameOrId("reports")
+		if err != nil {
+			return err
+		}
+		superusers, err := app.FindCollectionByNameOrId("_superusers")
+		if err != nil {
+			return err
+		}
+
+		reports.Fields.RemoveByName("resolver")
+		resolver := &core.RelationField{
+			Name:         "resolver",
+			CollectionId: superusers.Id,
+			Required:     false,
+			MaxSelect:    1,
+		}
+		resolver.SetId("relation_resolver_superusers")
+		reports.Fields.Add(resolver)
+
+		return app.Save(reports)
+	}, func(app core.App) error {
+		reports, err := app.FindCollectionByNameOrId("reports")
+		if err != nil {
+			return err
+		}
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		reports.Fields.RemoveByName("resolver")
+		reports.Fields.Add(&core.RelationField{
+			Name:         "resolver",
+			CollectionId: oracles.Id,
+			Required:     false,
+			MaxSelect:    1,
+		})
+
+		return app.Save(reports)
+	})
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// 1706745620_enable_oracle_oauth2.go turned on oracles.OAuth2.Enabled but
+// never registered a provider, so PocketBase's own OAuth2 endpoints (e.g.
+// /auth-with-oauth2) were enabled with nothing behind them - a dead,
+// misleading config surface. The actual GitHub flow lives at
+// /api/oracles/birth in hooks/oauth.go: it now builds its token
+// exchange/user lookup through PocketBase's core.OAuth2ProviderConfig
+// machinery rather than a hand-rolled HTTP client, but it's a one-shot
+// identity check chained onto an already-authenticated oracle, not a
+// generic "login with GitHub" - there's no collection-level provider for
+// this toggle to wire up. Leave it off.
+func init() {
+	m.Register(func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		oracles.OAuth2.Enabled = false
+
+		return app.Save(oracles)
+	}, func(app core.App) error {
+		oracles, err := app.FindCollectionByNameOrId("oracles")
+		if err != nil {
+			return err
+		}
+
+		oracles.OAuth2.Enabled = true
+
+		return app.Save(oracles)
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Soul-Brews-Studio/oracle-net/internal/testutil"
+	pbtests "github.com/pocketbase/pocketbase/tests"
+)
+
+// TestReverseProxyAuth exercises bindReverseProxyAuth's peer-trust check.
+// ApiScenario has no way to set a request's RemoteAddr directly, so the
+// simulated peer address is driven through X-Forwarded-For: the app is
+// configured to trust that header for e.RealIP(), letting the "trusted
+// peer" scenario set it to an address inside the allow-listed CIDR while
+// the "untrusted peer" scenario leaves it unset and falls back to
+// httptest.NewRequest's fixed default RemoteAddr (192.0.2.1), which is
+// outside it.
+func TestReverseProxyAuth(t *testing.T) {
+	t.Setenv("ORACLENET_REVERSE_PROXY_AUTH", "1")
+	t.Setenv("ORACLE_REVERSE_PROXY_AUTH_HEADER", "X-Authenticated-User")
+	t.Setenv("ORACLE_REVERSE_PROXY_TRUSTED_CIDRS", "10.0.0.0/8")
+
+	app := testutil.SetupTestApp(t)
+
+	app.Settings().TrustedProxy.Headers = []string{"X-Forwarded-For"}
+
+	scenarios := []pbtests.ApiScenario{
+		{
+			Name:   "trusted peer is authenticated via header",
+			Method: http.MethodGet,
+			URL:    "/api/oracles/me",
+			Headers: map[string]string{
+				"X-Authenticated-User": "approved@test.local",
+				"X-Forwarded-For":      "10.1.2.3",
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"id":`},
+		},
+		{
+			Name:   "untrusted peer is rejected even with the header set",
+			Method: http.MethodGet,
+			URL:    "/api/oracles/me",
+			Headers: map[string]string{
+				"X-Authenticated-User": "approved@test.local",
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"status":401`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			testutil.RunRequest(t, app, s)
+		})
+	}
+}